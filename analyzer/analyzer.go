@@ -0,0 +1,90 @@
+package analyzer
+
+import (
+	"fmt"
+	"io"
+	"math"
+
+	"wordler"
+	"wordler/solver"
+)
+
+// Turn is the analysis of one guess of a finished game.
+type Turn struct {
+	wordler.Row
+	Before, After int     // possible solutions before and after the guess
+	Expected      float64 // possible solutions expected to remain after the guess
+	Best          string  // the solver's guess at this point in the game
+	BestExpected  float64 // possible solutions expected to remain after Best
+	Skill         int     // 0-100: how Expected compares to BestExpected
+	Luck          int     // 0-100: how After compares to the other possible outcomes
+}
+
+// String fulfills the fmt.Stringer interface.
+func (t Turn) String() string {
+	return fmt.Sprintf("%s %s  %d -> %d words (expected %.1f); solver: %s (expected %.1f); skill %d, luck %d",
+		t.Guess, t.Response, t.Before, t.After, t.Expected, t.Best, t.BestExpected, t.Skill, t.Luck)
+}
+
+// Analyze replays rows, a finished game's guesses and responses, against s
+// and rates each guess against the guess s would have made instead. s should
+// be a new Solver; Analyze reacts to every row.
+func Analyze(s *solver.Solver, rows []wordler.Row) ([]Turn, error) {
+	var turns []Turn
+	for i, row := range rows {
+		t := Turn{Row: row, Before: s.Remaining(), Best: s.Guess()}
+		if t.Before == 0 {
+			return turns, fmt.Errorf("no possible solutions remain before guess %d ('%s')", i+1, row.Guess)
+		}
+
+		buckets := s.Buckets(row.Guess)
+		t.Expected = s.ExpectedRemaining(row.Guess)
+		t.BestExpected = s.ExpectedRemaining(t.Best)
+
+		if err := s.React(row.Guess, row.Response); err != nil {
+			return turns, fmt.Errorf("guess %d: %w", i+1, err)
+		}
+		t.After = s.Remaining()
+		t.Skill = skill(t.Before, t.Expected, t.BestExpected)
+		t.Luck = luck(buckets, t.Before, t.After)
+		turns = append(turns, t)
+	}
+	return turns, nil
+}
+
+// Report writes a report of turns to w, one line per turn.
+func Report(w io.Writer, turns []Turn) {
+	for i, t := range turns {
+		fmt.Fprintf(w, "%d. %v\n", i+1, t)
+	}
+}
+
+// skill compares the information gained by a guess to that gained by the
+// solver's guess, measuring both as the log of the expected reduction in
+// possible solutions. A guess at least as good as the solver's scores 100.
+func skill(before int, expected, best float64) int {
+	want, got := math.Log(float64(before)/best), math.Log(float64(before)/expected)
+	if want <= 0 || got >= want {
+		return 100
+	}
+	if got <= 0 {
+		return 0
+	}
+	return int(math.Round(100 * got / want))
+}
+
+// luck scores the actual outcome of a guess, leaving after of before
+// solutions, against all of its possible outcomes given by buckets. Leaving
+// fewer solutions than every other outcome scores 100; leaving more scores 0.
+func luck(buckets map[string]int, before, after int) int {
+	var score float64
+	for _, b := range buckets {
+		switch {
+		case b > after:
+			score += float64(b)
+		case b == after:
+			score += float64(b) / 2
+		}
+	}
+	return int(math.Round(100 * score / float64(before)))
+}
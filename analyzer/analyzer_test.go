@@ -0,0 +1,137 @@
+package analyzer
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"wordler"
+	"wordler/solver"
+	"wordler/wordlist"
+)
+
+func TestAnalyze(t *testing.T) {
+	list := []string{"crate", "crane", "trace", "slate", "sloth", "plate"}
+	rows := []wordler.Row{
+		{Guess: "crane", Response: wordler.Score("crane", "slate")},
+		{Guess: "slate", Response: wordler.Score("slate", "slate")},
+	}
+
+	turns, err := Analyze(solver.From(list), rows)
+	if err != nil {
+		t.Fatalf("got error %v", err)
+	}
+	if want, got := len(rows), len(turns); want != got {
+		t.Fatalf("want %d turns, got %d", want, got)
+	}
+
+	first := turns[0]
+	if want, got := len(list), first.Before; want != got {
+		t.Errorf("want %d words before, got %d", want, got)
+	}
+	// crane leaves slate and plate.
+	if want, got := 2, first.After; want != got {
+		t.Errorf("want %d words after, got %d", want, got)
+	}
+	// crane splits the list into {crate}, {crane}, {trace}, {slate, plate}, {sloth}.
+	if want, got := 8.0/6, first.Expected; want != got {
+		t.Errorf("want %v expected, got %v", want, got)
+	}
+	if first.BestExpected <= 0 {
+		t.Errorf("want positive expected words for %v, got %v", first.Best, first.BestExpected)
+	}
+	if first.Skill < 0 || first.Skill > 100 || first.Luck < 0 || first.Luck > 100 {
+		t.Errorf("want skill and luck in [0,100], got %d and %d", first.Skill, first.Luck)
+	}
+	// Every other outcome of crane leaves 1 word.
+	if want, got := 17, first.Luck; want != got {
+		t.Errorf("want luck %d, got %d", want, got)
+	}
+
+	last := turns[1]
+	if want, got := 2, last.Before; want != got {
+		t.Errorf("want %d words before, got %d", want, got)
+	}
+	if want, got := 1, last.After; want != got {
+		t.Errorf("want %d words after, got %d", want, got)
+	}
+	if want, got := 100, last.Skill; want != got {
+		t.Errorf("want skill %d, got %d", want, got)
+	}
+
+	var b bytes.Buffer
+	Report(&b, turns)
+	if want, got := 2, strings.Count(b.String(), "\n"); want != got {
+		t.Errorf("want %d lines, got %d: %v", want, got, b.String())
+	}
+	if !strings.HasPrefix(b.String(), "1. crane") {
+		t.Errorf("want report to start with the first guess, got %v", b.String())
+	}
+}
+
+func TestAnalyzeFrequency(t *testing.T) {
+	// Weighted by frequency, analyzer and solver agree on what to expect.
+	list := []string{"crate", "crane", "trace", "slate", "sloth", "plate"}
+	freq := wordlist.FrequencyOption{Freq: map[string]float64{"slate": 10, "plate": 10, "crate": 1}}
+	rows := []wordler.Row{{Guess: "crane", Response: wordler.Score("crane", "slate")}}
+	turns, err := Analyze(solver.From(list, freq), rows)
+	if err != nil {
+		t.Fatalf("got error %v", err)
+	}
+	s := solver.From(list, freq)
+	if want, got := s.ExpectedRemaining("crane"), turns[0].Expected; want != got {
+		t.Errorf("want %v expected, got %v", want, got)
+	}
+	if unweighted := 8.0 / 6; turns[0].Expected == unweighted {
+		t.Errorf("want frequencies to change the expected %v", unweighted)
+	}
+}
+
+func TestAnalyzeContradiction(t *testing.T) {
+	rows := []wordler.Row{
+		{Guess: "crane", Response: "_____"},
+		{Guess: "slate", Response: "_____"},
+	}
+	turns, err := Analyze(solver.From([]string{"slate", "plate"}), rows)
+	if err == nil {
+		t.Error("want error, got nil")
+	}
+	if want, got := 1, len(turns); want != got {
+		t.Errorf("want %d turns, got %d", want, got)
+	}
+}
+
+func TestSkill(t *testing.T) {
+	cases := []struct {
+		before         int
+		expected, best float64
+		want           int
+	}{
+		{100, 10, 10, 100},
+		{100, 5, 10, 100},
+		{100, 100, 10, 0},
+		{100, 10, 1, 50},
+		{1, 1, 1, 100},
+	}
+	for _, c := range cases {
+		if got := skill(c.before, c.expected, c.best); c.want != got {
+			t.Errorf("skill(%d, %v, %v): want %d, got %d", c.before, c.expected, c.best, c.want, got)
+		}
+	}
+}
+
+func TestLuck(t *testing.T) {
+	buckets := map[string]int{"a": 1, "b": 1, "c": 2, "d": 6}
+	cases := []struct {
+		after, want int
+	}{
+		{1, 90},
+		{2, 70},
+		{6, 30},
+	}
+	for _, c := range cases {
+		if got := luck(buckets, 10, c.after); c.want != got {
+			t.Errorf("luck(%d): want %d, got %d", c.after, c.want, got)
+		}
+	}
+}
@@ -0,0 +1,78 @@
+package main
+
+import (
+	"bufio"
+	"flag"
+	"fmt"
+	"os"
+	"regexp"
+	"strings"
+
+	"wordler"
+	"wordler/analyzer"
+	"wordler/solver"
+	"wordler/wordlist"
+)
+
+func main() {
+	local := flag.Bool("local_dictionary", false, "use local dictionary in place of Wordle dictionary")
+	dictionary := flag.String("dictionary", "", "load words from this file, one per line (optionally gzip-compressed), in place of Wordle dictionary")
+	length := flag.Int("length", wordler.DEFAULT_WORD_LENGTH, "word length")
+	usage := flag.Usage
+	flag.Usage = func() {
+		usage()
+		fmt.Fprintf(flag.CommandLine.Output(), "\nPositional arguments are taken as the game's rows, e.g. 'crane:__+*_'.\n")
+		fmt.Fprintf(flag.CommandLine.Output(), "Without positional arguments, rows are read from stdin, one per line.\n")
+	}
+	flag.Parse()
+
+	var rows []wordler.Row
+	add := func(s string) {
+		if strings.TrimSpace(s) == "" {
+			return
+		}
+		row, err := wordler.ParseRow(s)
+		if err != nil {
+			fmt.Println("ERROR: ", err)
+			os.Exit(2)
+		}
+		rows = append(rows, row)
+	}
+	if flag.NArg() > 0 {
+		for _, arg := range flag.Args() {
+			add(arg)
+		}
+	} else {
+		in := bufio.NewScanner(os.Stdin)
+		for in.Scan() {
+			add(in.Text())
+		}
+	}
+	if len(rows) == 0 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	var loader wordlist.DictionaryLoader // nil means the local dictionary
+	if *dictionary != "" {
+		loader = wordlist.NewFileLoader(*dictionary)
+	}
+	var s *solver.Solver
+	var err error
+	if *local || loader != nil {
+		s, err = solver.Load(loader, wordlist.KeepOnlyOption{Exp: regexp.MustCompile(fmt.Sprintf(`^\p{Ll}{%d}$`, *length))})
+	} else {
+		s, err = solver.ForLength(*length)
+	}
+	if err != nil {
+		fmt.Printf("Failed to make a Solver: %v\n", err)
+		os.Exit(2)
+	}
+
+	turns, err := analyzer.Analyze(s, rows)
+	analyzer.Report(os.Stdout, turns)
+	if err != nil {
+		fmt.Println("ERROR: ", err)
+		os.Exit(1)
+	}
+}
@@ -0,0 +1,158 @@
+package language
+
+import (
+	"bytes"
+	_ "embed"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"sync"
+
+	"wordler"
+	"wordler/wordlist"
+)
+
+// DEFAULT is the code of the default Language, English.
+const DEFAULT = "en"
+
+// Language bundles what's needed to play in a language: its dictionary, its
+// alphabet and the strings the commands print.
+type Language struct {
+	Code, Name string
+	// Keyboard holds the rows of keys of the language's usual keyboard; the
+	// alphabet is every letter on it.
+	Keyboard []string
+
+	words    []byte            // the dictionary, one word per line; nil means wordler.Dictionary
+	messages map[string]string // translations of the commands' English strings
+
+	once   sync.Once // guards building loader
+	loader wordlist.DictionaryLoader
+}
+
+var (
+	NotInAlphabetErr = errors.New("not in the alphabet")
+
+	//go:embed dict/es.txt
+	spanish []byte
+	//go:embed dict/de.txt
+	german []byte
+
+	languages = map[string]*Language{
+		"en": {
+			Code:     "en",
+			Name:     "English",
+			Keyboard: []string{"qwertyuiop", "asdfghjkl", "zxcvbnm"},
+		},
+		"es": {
+			Code:     "es",
+			Name:     "Español",
+			Keyboard: []string{"qwertyuiop", "asdfghjklñ", "zxcvbnm"},
+			words:    spanish,
+			messages: spanishMessages,
+		},
+		"de": {
+			Code:     "de",
+			Name:     "Deutsch",
+			Keyboard: []string{"qwertzuiopü", "asdfghjklöä", "yxcvbnmß"},
+			words:    german,
+			messages: germanMessages,
+		},
+	}
+)
+
+// Get returns the Language with the given code, e.g. "es".
+func Get(code string) (*Language, error) {
+	if l, ok := languages[strings.ToLower(code)]; ok {
+		return l, nil
+	}
+	return nil, fmt.Errorf("unknown language %q: want one of %s", code, strings.Join(Codes(), ", "))
+}
+
+// Codes returns the codes of the available Languages, in order.
+func Codes() []string {
+	var codes []string
+	for code := range languages {
+		codes = append(codes, code)
+	}
+	sort.Strings(codes)
+	return codes
+}
+
+// Alphabet returns the letters of the Language, in keyboard order.
+func (l *Language) Alphabet() string {
+	return strings.Join(l.Keyboard, "")
+}
+
+// Validate returns an error if word has a letter that isn't in the alphabet.
+func (l *Language) Validate(word string) error {
+	alphabet := l.Alphabet()
+	for _, c := range word {
+		if !strings.ContainsRune(alphabet, c) {
+			return fmt.Errorf("'%s' has '%c', which is %w (%s)", word, c, NotInAlphabetErr, l.Name)
+		}
+	}
+	return nil
+}
+
+// Option returns an Option that keeps only the words spelled with the alphabet.
+func (l *Language) Option() wordlist.Option {
+	return wordlist.KeepOnlyOption{Exp: regexp.MustCompile("^[" + regexp.QuoteMeta(l.Alphabet()) + "]+$")}
+}
+
+// Loader returns a DictionaryLoader for the Language's dictionary, or nil if
+// the Language uses wordler.Dictionary. Every call returns the same loader, so
+// the dictionary is only read once.
+func (l *Language) Loader() wordlist.DictionaryLoader {
+	if l.words == nil {
+		return nil
+	}
+	l.once.Do(func() {
+		l.loader = wordlist.NewReaderLoader(bytes.NewReader(l.words))
+	})
+	return l.loader
+}
+
+// T translates s, one of the commands' English strings; strings without a
+// translation are returned as they are.
+func (l *Language) T(s string) string {
+	if t, ok := l.messages[s]; ok {
+		return t
+	}
+	return s
+}
+
+// ShowKeyboard returns the Keyboard, one row per line, with each key followed
+// by the best response its letter got in rows: wordler.CORRECT,
+// wordler.ELSEWHERE or wordler.NIL, or a space if it hasn't been guessed.
+func (l *Language) ShowKeyboard(rows []wordler.Row) string {
+	rank := map[rune]int{wordler.NIL: 1, wordler.ELSEWHERE: 2, wordler.CORRECT: 3}
+	best := make(map[rune]rune)
+	for _, row := range rows {
+		response := []rune(row.Response)
+		for i, c := range []rune(row.Guess) {
+			if i < len(response) && rank[response[i]] > rank[best[c]] {
+				best[c] = response[i]
+			}
+		}
+	}
+
+	var b strings.Builder
+	for i, keys := range l.Keyboard {
+		var row strings.Builder
+		row.WriteString(strings.Repeat(" ", i))
+		for _, c := range keys {
+			mark, ok := best[c]
+			if !ok {
+				mark = ' '
+			}
+			row.WriteRune(c)
+			row.WriteRune(mark)
+			row.WriteRune(' ')
+		}
+		b.WriteString(strings.TrimRight(row.String(), " ") + "\n")
+	}
+	return b.String()
+}
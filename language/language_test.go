@@ -0,0 +1,140 @@
+package language
+
+import (
+	"errors"
+	"regexp"
+	"testing"
+
+	"wordler"
+	"wordler/puzzler"
+	"wordler/solver"
+	"wordler/wordlist"
+)
+
+func TestGet(t *testing.T) {
+	for _, code := range Codes() {
+		l, err := Get(code)
+		if err != nil || l.Code != code {
+			t.Errorf("%s: got %v (err %v)", code, l, err)
+		}
+	}
+	if l, err := Get("ES"); err != nil || l.Code != "es" {
+		t.Errorf("ES: got %v (err %v)", l, err)
+	}
+	if l, err := Get("xx"); err == nil {
+		t.Errorf("xx: want error, got %v", l)
+	}
+	if l, err := Get(DEFAULT); err != nil || l.Loader() != nil {
+		t.Errorf("%s should use the Wordle dictionary; got %v (err %v)", DEFAULT, l, err)
+	}
+}
+
+func TestDictionaries(t *testing.T) {
+	for _, code := range Codes() {
+		l, _ := Get(code)
+		loader := l.Loader()
+		if loader == nil {
+			continue
+		}
+		if l.Loader() != loader {
+			t.Errorf("%s: want the same loader from every call", code)
+		}
+		all, err := loader.Load()
+		if err != nil {
+			t.Fatalf("%s: %v", code, err)
+		}
+		valid, _ := loader.Load(l.Option(), wordlist.KeepOnlyOption{Exp: regexp.MustCompile(`^.{5}$`)})
+		if all.Length() < 100 || !all.Equals(valid) {
+			t.Errorf("%s: want 100+ 5-letter words in the alphabet, got %d of %d", code, valid.Length(), all.Length())
+		}
+	}
+}
+
+func TestValidate(t *testing.T) {
+	cases := []struct {
+		code, word string
+		err        error
+	}{
+		{"en", "crane", nil},
+		{"en", "señal", NotInAlphabetErr},
+		{"es", "señal", nil},
+		{"es", "größe", NotInAlphabetErr},
+		{"de", "größe", nil},
+		{"de", "Größe", NotInAlphabetErr},
+	}
+	for _, c := range cases {
+		l, _ := Get(c.code)
+		if err := l.Validate(c.word); !errors.Is(err, c.err) {
+			t.Errorf("%s %q: want %v, got %v", c.code, c.word, c.err, err)
+		}
+	}
+}
+
+func TestMessages(t *testing.T) {
+	verbs := regexp.MustCompile(`%[^%]|\n$`)
+	for _, code := range Codes() {
+		l, _ := Get(code)
+		for s, translation := range l.messages {
+			want, got := verbs.FindAllString(s, -1), verbs.FindAllString(translation, -1)
+			if len(want) != len(got) {
+				t.Errorf("%s: %q has verbs %q, translation %q has %q", code, s, want, translation, got)
+				continue
+			}
+			for i := range want {
+				if want[i] != got[i] {
+					t.Errorf("%s: %q has verbs %q, translation %q has %q", code, s, want, translation, got)
+					break
+				}
+			}
+		}
+	}
+	es, _ := Get("es")
+	if want, got := "¡GANASTE!", es.T("YOU WIN!"); want != got {
+		t.Errorf("want %q, got %q", want, got)
+	}
+	if want, got := "untranslated", es.T("untranslated"); want != got {
+		t.Errorf("want %q, got %q", want, got)
+	}
+}
+
+func TestShowKeyboard(t *testing.T) {
+	es, _ := Get("es")
+	rows := []wordler.Row{
+		{Guess: "señal", Response: "*++__"},
+		{Guess: "leñas", Response: "++++_"},
+	}
+	want := "q  w  e+ r  t  y  u  i  o  p\n" +
+		" a+ s* d  f  g  h  j  k  l+ ñ+\n" +
+		"  z  x  c  v  b  n  m\n"
+	if got := es.ShowKeyboard(rows); want != got {
+		t.Errorf("want\n%s\ngot\n%s", want, got)
+	}
+}
+
+// TestGame plays a Spanish game to make sure the engine copes with the
+// language's alphabet.
+func TestGame(t *testing.T) {
+	es, _ := Get("es")
+	p, err := puzzler.New(&puzzler.Args{Hard: true, WordLength: 5, Guesses: 6, Solution: "señor", Loader: es.Loader()})
+	if err != nil {
+		t.Fatal(err)
+	}
+	s, err := solver.Load(es.Loader(), es.Option())
+	if err != nil {
+		t.Fatal(err)
+	}
+	for p.Guesses() > 0 {
+		guess := s.Guess()
+		response, err := p.Guess(guess)
+		if err != nil {
+			t.Fatalf("guess %q: %v", guess, err)
+		}
+		if guess == "señor" {
+			return
+		}
+		if err := s.React(guess, response); err != nil {
+			t.Fatalf("guess %q, response %q: %v", guess, response, err)
+		}
+	}
+	t.Errorf("failed to find 'señor' in %v", p.History())
+}
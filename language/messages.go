@@ -0,0 +1,131 @@
+package language
+
+// Translations of the commands' English strings; format verbs must match.
+
+var spanishMessages = map[string]string{
+	// Shared.
+	"I only allow %d-letter words found in %s.\n":                   "Solo acepto palabras de %d letras de %s.\n",
+	"I only allow %d-letter words found in the local dictionary.\n": "Solo acepto palabras de %d letras del diccionario local.\n",
+	"I only allow %d-letter words in %s.\n":                         "Solo acepto palabras de %d letras en %s.\n",
+	"I only allow %d-letter words from my bundled word lists.\n":    "Solo acepto palabras de %d letras de mis listas incluidas.\n",
+	"Ready? Here we go!": "¿Listo? ¡Vamos!",
+
+	// puzzler
+	"I'm a wordle puzzle! You make guesses, I'll score them.":                           "¡Soy un wordle! Tú adivinas y yo puntúo.",
+	"I'll use '%c' for \"right letter in the right place\"\n":                           "Usaré '%c' para \"letra correcta en el lugar correcto\"\n",
+	"I'll use '%c' for \"right letter in the wrong place\"\n":                           "Usaré '%c' para \"letra correcta en el lugar equivocado\"\n",
+	"I'll use '%c' for \"letter not in the word\"\n":                                    "Usaré '%c' para \"letra que no está en la palabra\"\n",
+	"I'll respond with the letter 'n' by itself if your guess isn't in the dictionary.": "Responderé solo con la letra 'n' si tu intento no está en el diccionario.",
+	"You've got %d guesses.\n":                                                          "Tienes %d intentos.\n",
+	"%d guesses and %d words remain.\n":                                                 "Quedan %d intentos y %d palabras.\n",
+	"Your guess? ":                                                                      "¿Tu intento? ",
+	"Invalid guess: ":                                                                   "Intento no válido: ",
+	"Uh oh, no words remaining!?":                                                       "¡¿Vaya, no quedan palabras?!",
+	"YOU WIN!":                                                                          "¡GANASTE!",
+	"Response:  ":                                                                       "Respuesta: ",
+	"YOU LOSE!":                                                                         "¡PERDISTE!",
+	"The solution is '%v'.\n":                                                           "La solución es '%v'.\n",
+	"Here's how you did:":                                                               "Así lo hiciste:",
+	"You're playing %d boards at once; each guess counts on every board.\n": "Juegas %d tableros a la vez; cada intento cuenta en todos.\n",
+	"%d guesses remain; words remaining on unsolved boards: %s.\n":          "Quedan %d intentos; palabras posibles en los tableros sin resolver: %s.\n",
+	"Board %d: solved\n":      "Tablero %d: resuelto\n",
+	"Board %d: %s  SOLVED!\n": "Tablero %d: %s  ¡RESUELTO!\n",
+	"Board %d: %s\n":          "Tablero %d: %s\n",
+	"The solutions are %s.\n": "Las soluciones son %s.\n",
+	"I'm Fibble: I lie about exactly one letter in every response, unless you win.": "Soy Fibble: miento sobre exactamente una letra en cada respuesta, salvo si ganas.",
+
+	// solver
+	"I'm a wordle solver! I'll make up to %d guesses, you tell me wordle's response.\n":                                        "¡Resuelvo wordles! Haré hasta %d intentos; tú me dices la respuesta de wordle.\n",
+	"Use '%c' for \"right letter in the right place\"\n":                                                                       "Usa '%c' para \"letra correcta en el lugar correcto\"\n",
+	"Use '%c' for \"right letter in the wrong place\"\n":                                                                       "Usa '%c' para \"letra correcta en el lugar equivocado\"\n",
+	"Use '%c' for \"letter not in the word\"\n":                                                                                "Usa '%c' para \"letra que no está en la palabra\"\n",
+	"You can also use colors (\"gyb\" or \"gyx\"), emoji squares (🟩🟨⬛) or digits (\"210\").":                                   "También puedes usar colores (\"gyb\" o \"gyx\"), cuadrados emoji (🟩🟨⬛) o dígitos (\"210\").",
+	"Respond with the letter 'n' by itself to tell me that my guess isn't in wordle's dictionary.":                             "Responde solo con la letra 'n' si mi intento no está en el diccionario de wordle.",
+	"Respond with the letter 'y' by itself to tell me that I've solved the wordle.":                                            "Responde solo con la letra 'y' si he resuelto el wordle.",
+	"Respond with 'why <word>' to ask me why <word> isn't a possible solution.":                                                "Responde 'why <palabra>' para preguntarme por qué <palabra> no es una solución posible.",
+	"Respond with 'list [-w] [page]' to see the possible words, optionally with their frequencies or letter-frequency scores.": "Responde 'list [-w] [página]' para ver las palabras posibles, opcionalmente con sus frecuencias o sus puntuaciones de frecuencia de letras.",
+	"Respond with 'known' to see what I know about the solution so far.":                                                       "Responde 'known' para ver lo que sé de la solución hasta ahora.",
+	"Respond with 'fix <row> <response>' to correct an earlier response.":                                                      "Responde 'fix <fila> <respuesta>' para corregir una respuesta anterior.",
+	"No words fit your responses.":                                                                                             "Ninguna palabra encaja con tus respuestas.",
+	"Correction? ":                                                                                                             "¿Corrección? ",
+	"The word is ":                                                                                                             "La palabra es ",
+	"I've got %d possible words and %d guesses left.\n":                                                                        "Me quedan %d palabras posibles y %d intentos.\n",
+	"Guess: ":            "Intento: ",
+	"Response? ":         "¿Respuesta? ",
+	"Out of guesses :-(": "Sin intentos :-(",
+	"I'll allow for up to %d wrong marks in each response.\n": "Tendré en cuenta hasta %d marcas erróneas en cada respuesta.\n",
+
+	// wordler
+	"I'm a wordler! I try to solve wordle puzzles and report on my success.": "¡Soy un wordler! Intento resolver wordles e informo de mi éxito.",
+	"I allow %d guesses for each of %d iterations.\n":                        "Permito %d intentos en cada una de %d iteraciones.\n",
+	"I won %.2f%% of games played with an average of %.2f guesses.\n":        "Gané el %.2f%% de las partidas con una media de %.2f intentos.\n",
+	"I play %d boards at once.\n":                                            "Juego %d tableros a la vez.\n",
+	"I solved %.2f%% of boards.\n":                                           "Resolví el %.2f%% de los tableros.\n",
+	"I play Absurdle: the solution changes to dodge every guess.":            "Juego a Absurdle: la solución cambia para esquivar cada intento.",
+	"I play Fibble: one mark in every response is a lie.":                    "Juego a Fibble: una marca de cada respuesta es mentira.",
+	"I consult the opening book in %s before guessing.\n":                    "Consulto el libro de aperturas de %s antes de adivinar.\n",
+}
+
+var germanMessages = map[string]string{
+	// Shared.
+	"I only allow %d-letter words found in %s.\n":                   "Ich erlaube nur Wörter mit %d Buchstaben aus %s.\n",
+	"I only allow %d-letter words found in the local dictionary.\n": "Ich erlaube nur Wörter mit %d Buchstaben aus dem lokalen Wörterbuch.\n",
+	"I only allow %d-letter words in %s.\n":                         "Ich erlaube nur Wörter mit %d Buchstaben auf %s.\n",
+	"I only allow %d-letter words from my bundled word lists.\n":    "Ich erlaube nur Wörter mit %d Buchstaben aus meinen mitgelieferten Wortlisten.\n",
+	"Ready? Here we go!": "Bereit? Los geht's!",
+
+	// puzzler
+	"I'm a wordle puzzle! You make guesses, I'll score them.":                           "Ich bin ein Wordle! Du rätst, ich bewerte.",
+	"I'll use '%c' for \"right letter in the right place\"\n":                           "Ich verwende '%c' für \"richtiger Buchstabe an der richtigen Stelle\"\n",
+	"I'll use '%c' for \"right letter in the wrong place\"\n":                           "Ich verwende '%c' für \"richtiger Buchstabe an der falschen Stelle\"\n",
+	"I'll use '%c' for \"letter not in the word\"\n":                                    "Ich verwende '%c' für \"Buchstabe nicht im Wort\"\n",
+	"I'll respond with the letter 'n' by itself if your guess isn't in the dictionary.": "Ich antworte nur mit dem Buchstaben 'n', wenn dein Versuch nicht im Wörterbuch steht.",
+	"You've got %d guesses.\n":                                                          "Du hast %d Versuche.\n",
+	"%d guesses and %d words remain.\n":                                                 "Noch %d Versuche und %d Wörter.\n",
+	"Your guess? ":                                                                      "Dein Versuch? ",
+	"Invalid guess: ":                                                                   "Ungültiger Versuch: ",
+	"Uh oh, no words remaining!?":                                                       "Oje, keine Wörter mehr übrig!?",
+	"YOU WIN!":                                                                          "GEWONNEN!",
+	"Response:  ":                                                                       "Antwort:  ",
+	"YOU LOSE!":                                                                         "VERLOREN!",
+	"The solution is '%v'.\n":                                                           "Die Lösung ist '%v'.\n",
+	"Here's how you did:":                                                               "So hast du dich geschlagen:",
+	"You're playing %d boards at once; each guess counts on every board.\n": "Du spielst %d Bretter gleichzeitig; jeder Versuch zählt auf allen.\n",
+	"%d guesses remain; words remaining on unsolved boards: %s.\n":          "Noch %d Versuche; mögliche Wörter auf den ungelösten Brettern: %s.\n",
+	"Board %d: solved\n":      "Brett %d: gelöst\n",
+	"Board %d: %s  SOLVED!\n": "Brett %d: %s  GELÖST!\n",
+	"Board %d: %s\n":          "Brett %d: %s\n",
+	"The solutions are %s.\n": "Die Lösungen sind %s.\n",
+	"I'm Fibble: I lie about exactly one letter in every response, unless you win.": "Ich bin Fibble: Ich lüge bei genau einem Buchstaben jeder Antwort, außer wenn du gewinnst.",
+
+	// solver
+	"I'm a wordle solver! I'll make up to %d guesses, you tell me wordle's response.\n":                                        "Ich löse Wordles! Ich rate bis zu %d Mal, du sagst mir die Antwort von Wordle.\n",
+	"Use '%c' for \"right letter in the right place\"\n":                                                                       "Verwende '%c' für \"richtiger Buchstabe an der richtigen Stelle\"\n",
+	"Use '%c' for \"right letter in the wrong place\"\n":                                                                       "Verwende '%c' für \"richtiger Buchstabe an der falschen Stelle\"\n",
+	"Use '%c' for \"letter not in the word\"\n":                                                                                "Verwende '%c' für \"Buchstabe nicht im Wort\"\n",
+	"You can also use colors (\"gyb\" or \"gyx\"), emoji squares (🟩🟨⬛) or digits (\"210\").":                                   "Du kannst auch Farben (\"gyb\" oder \"gyx\"), Emoji-Quadrate (🟩🟨⬛) oder Ziffern (\"210\") verwenden.",
+	"Respond with the letter 'n' by itself to tell me that my guess isn't in wordle's dictionary.":                             "Antworte nur mit dem Buchstaben 'n', wenn mein Versuch nicht in Wordles Wörterbuch steht.",
+	"Respond with the letter 'y' by itself to tell me that I've solved the wordle.":                                            "Antworte nur mit dem Buchstaben 'y', wenn ich das Wordle gelöst habe.",
+	"Respond with 'why <word>' to ask me why <word> isn't a possible solution.":                                                "Antworte mit 'why <Wort>', um zu fragen, warum <Wort> keine mögliche Lösung ist.",
+	"Respond with 'list [-w] [page]' to see the possible words, optionally with their frequencies or letter-frequency scores.": "Antworte mit 'list [-w] [Seite]', um die möglichen Wörter zu sehen, wahlweise mit ihren Häufigkeiten oder Buchstabenhäufigkeits-Punkten.",
+	"Respond with 'known' to see what I know about the solution so far.":                                                       "Antworte mit 'known', um zu sehen, was ich bisher über die Lösung weiß.",
+	"Respond with 'fix <row> <response>' to correct an earlier response.":                                                      "Antworte mit 'fix <Zeile> <Antwort>', um eine frühere Antwort zu korrigieren.",
+	"No words fit your responses.":                                                                                             "Kein Wort passt zu deinen Antworten.",
+	"Correction? ":                                                                                                             "Korrektur? ",
+	"The word is ":                                                                                                             "Das Wort ist ",
+	"I've got %d possible words and %d guesses left.\n":                                                                        "Ich habe noch %d mögliche Wörter und %d Versuche.\n",
+	"Guess: ":            "Versuch: ",
+	"Response? ":         "Antwort? ",
+	"Out of guesses :-(": "Keine Versuche mehr :-(",
+	"I'll allow for up to %d wrong marks in each response.\n": "Ich rechne mit bis zu %d falschen Markierungen in jeder Antwort.\n",
+
+	// wordler
+	"I'm a wordler! I try to solve wordle puzzles and report on my success.": "Ich bin ein Wordler! Ich versuche, Wordles zu lösen, und berichte über meinen Erfolg.",
+	"I allow %d guesses for each of %d iterations.\n":                        "Ich erlaube %d Versuche in jeder von %d Runden.\n",
+	"I won %.2f%% of games played with an average of %.2f guesses.\n":        "Ich habe %.2f%% der Spiele mit durchschnittlich %.2f Versuchen gewonnen.\n",
+	"I play %d boards at once.\n":                                            "Ich spiele %d Bretter gleichzeitig.\n",
+	"I solved %.2f%% of boards.\n":                                           "Ich habe %.2f%% der Bretter gelöst.\n",
+	"I play Absurdle: the solution changes to dodge every guess.":            "Ich spiele Absurdle: Die Lösung weicht jedem Versuch aus.",
+	"I play Fibble: one mark in every response is a lie.":                    "Ich spiele Fibble: Eine Markierung jeder Antwort ist gelogen.",
+	"I consult the opening book in %s before guessing.\n":                    "Ich schaue vor dem Raten im Eröffnungsbuch %s nach.\n",
+}
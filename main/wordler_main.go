@@ -1,6 +1,7 @@
 package main
 
 import (
+	"errors"
 	"flag"
 	"fmt"
 	"os"
@@ -8,6 +9,7 @@ import (
 	"strings"
 
 	"wordler"
+	"wordler/language"
 	"wordler/puzzler"
 	"wordler/solver"
 	"wordler/wordlist"
@@ -25,11 +27,19 @@ func main() {
 	args := &puzzler.Args{}
 	flag.BoolVar(&args.Hard, "hard", true, "use hard rules: 'Any revealed hints must be used in subsequent guesses'")
 	local := flag.Bool("local_dictionary", false, "use local dictionary in place of Wordle dictionary")
+	dictionary := flag.String("dictionary", "", "load words from this file, one per line (optionally gzip-compressed), in place of Wordle dictionary")
 	flag.IntVar(&args.WordLength, "length", wordler.DEFAULT_WORD_LENGTH, "word length")
 	flag.IntVar(&args.Guesses, "guesses", wordler.DEFAULT_GUESSES, "number of guesses allowed")
 	flag.StringVar(&args.Solution, "solution", "", "puzzler will use the specified solution")
 	iterations := flag.Int("iterations", 10, "number of iterations to run")
+	frequencies := flag.String("frequencies", "", "file of word frequencies ('<word> <frequency>' per line); solver treats frequent words as likelier solutions")
+	weighted := flag.Bool("weighted", false, "draw solutions in proportion to their --frequencies rather than uniformly")
 	flag.IntVar(&verbosity, "verbosity", verbosity, "-2 (silent); -1 (no debug output); 0+ increasing verbosity")
+	code := flag.String("lang", language.DEFAULT, fmt.Sprintf("language: one of %s", strings.Join(language.Codes(), ", ")))
+	boards := flag.Int("boards", 1, "number of boards, each with its own hidden word, that share the guesses: 2 for Dordle, 4 for Quordle, 8 for Octordle")
+	fibble := flag.Bool("fibble", false, "play against Fibble, which lies about one mark in every response; the solver allows for one lie per response")
+	absurdle := flag.Bool("absurdle", false, "play against Absurdle, which has no fixed solution and answers each guess so as to leave the most words")
+	bookFile := flag.String("book", "", "the solver makes the guesses in this opening book, as written by solver/main --export-tree=book, before computing any")
 	usage := flag.Usage
 	flag.Usage = func() {
 		usage()
@@ -38,15 +48,87 @@ func main() {
 	flag.Parse()
 	clGuesses := flag.Args()
 
-	fmt.Println("I'm a wordler! I try to solve wordle puzzles and report on my success.")
-	if *local {
-		fmt.Printf("I only allow %d-letter words found in the local dictionary.\n", args.WordLength)
+	lang, err := language.Get(*code)
+	if err != nil {
+		fmt.Println("ERROR: ", err)
+		os.Exit(2)
+	}
+	args.Options = append(args.Options, lang.Option())
+	if *absurdle && (*boards > 1 || args.Solution != "") {
+		fmt.Println("ERROR: --absurdle cannot be used with --boards or --solution")
+		os.Exit(2)
+	}
+	if *fibble && (*boards > 1 || *absurdle) {
+		fmt.Println("ERROR: --fibble cannot be used with --boards or --absurdle")
+		os.Exit(2)
+	}
+	var book solver.Book
+	if *bookFile != "" {
+		if *boards > 1 {
+			fmt.Println("ERROR: --book cannot be used with --boards")
+			os.Exit(2)
+		}
+		if book, err = solver.LoadBook(*bookFile); err != nil {
+			fmt.Println("ERROR: ", err)
+			os.Exit(2)
+		}
+	}
+	guessesSet := false
+	flag.Visit(func(f *flag.Flag) { guessesSet = guessesSet || f.Name == "guesses" })
+	if *fibble && !guessesSet {
+		args.Guesses = puzzler.FibbleGuesses
+	}
+	if *boards > 1 {
+		if !guessesSet {
+			args.Guesses = puzzler.MultiGuesses(*boards)
+		}
+	}
+
+	var priors []wordlist.Option // solver's prior solution probabilities
+	if *frequencies != "" {
+		freq, err := wordlist.LoadFrequencyFile(*frequencies)
+		if err != nil {
+			fmt.Println("ERROR: ", err)
+			os.Exit(2)
+		}
+		priors = append(priors, wordlist.FrequencyOption{Freq: freq})
+		if *weighted {
+			args.Options = append(args.Options, priors...)
+		}
+	} else if *weighted {
+		fmt.Println("ERROR: --weighted requires --frequencies")
+		os.Exit(2)
+	}
+
+	fmt.Println(lang.T("I'm a wordler! I try to solve wordle puzzles and report on my success."))
+	if *dictionary != "" {
+		fmt.Printf(lang.T("I only allow %d-letter words found in %s.\n"), args.WordLength, *dictionary)
+		args.Loader = wordlist.NewFileLoader(*dictionary)
+	} else if *local {
+		fmt.Printf(lang.T("I only allow %d-letter words found in the local dictionary.\n"), args.WordLength)
 		args.Dictionary = puzzler.LocalDictionary
+	} else if args.Loader = lang.Loader(); args.Loader != nil {
+		fmt.Printf(lang.T("I only allow %d-letter words in %s.\n"), args.WordLength, lang.Name)
+	}
+	fmt.Printf(lang.T("I allow %d guesses for each of %d iterations.\n"), args.Guesses, *iterations)
+	if *boards > 1 {
+		fmt.Printf(lang.T("I play %d boards at once.\n"), *boards)
+	}
+	if *absurdle {
+		fmt.Println(lang.T("I play Absurdle: the solution changes to dodge every guess."))
+	}
+	if *fibble {
+		fmt.Println(lang.T("I play Fibble: one mark in every response is a lie."))
+	}
+	if *weighted {
+		fmt.Println("I draw solutions in proportion to how frequently they're used.")
 	}
-	fmt.Printf("I allow %d guesses for each of %d iterations.\n", args.Guesses, *iterations)
 	if args.Solution != "" {
 		fmt.Printf("I'll always use '%v' as my solution.\n", args.Solution)
 	}
+	if *bookFile != "" {
+		fmt.Printf(lang.T("I consult the opening book in %s before guessing.\n"), *bookFile)
+	}
 	if len(clGuesses) > 0 {
 		if *iterations != 1 && clGuesses[len(clGuesses)-1] == args.Solution {
 			fmt.Println("NOTE: last guess is solution; setting iterations to 1.")
@@ -55,17 +137,36 @@ func main() {
 			fmt.Printf("My first guesses, in order, will be %v.\n", strings.Join(clGuesses, ", "))
 		}
 	}
-	fmt.Println("Ready? Here we go!")
+	fmt.Println(lang.T("Ready? Here we go!"))
 	fmt.Println()
 
+	options := []wordlist.Option{wordlist.KeepOnlyOption{Exp: regexp.MustCompile(fmt.Sprintf("^.{%d}$", args.WordLength))}, lang.Option()}
+	if *boards > 1 {
+		newSolver := func() (*solver.Multi, error) {
+			if *local || args.Loader != nil {
+				return solver.LoadMulti(*boards, args.Loader, append(options, priors...)...)
+			}
+			return solver.MultiForLength(*boards, args.WordLength, append([]wordlist.Option{lang.Option()}, priors...)...)
+		}
+		simulateMulti(lang, args, *boards, *iterations, newSolver, clGuesses)
+		return
+	}
+
 	count := stats{}
 
-	option := wordlist.KeepOnlyOption{Exp: regexp.MustCompile(fmt.Sprintf("^.{%d}$", args.WordLength))}
 	winningResponse := strings.Repeat(string(wordler.CORRECT), args.WordLength)
 	for i := 0; i < *iterations; i++ {
 		count.iterations++
 		debug(-1, "Iteration %d/%d: ", i+1, *iterations)
-		p, err := puzzler.New(args)
+		var p puzzler.Puzzler
+		switch {
+		case *absurdle:
+			p, err = puzzler.NewAbsurdle(args)
+		case *fibble:
+			p, err = puzzler.NewFibble(args)
+		default:
+			p, err = puzzler.New(args)
+		}
 		if err != nil {
 			count.puzzlerFailures++
 			fmt.Printf("Failed to make a Puzzler: %v\n", err)
@@ -73,21 +174,28 @@ func main() {
 		}
 
 		var s *solver.Solver
-		if *local {
-			if s, err = solver.New(option); err != nil {
-				count.solverFailures++
-				fmt.Printf("Failed to make a Solver: %v\n", err)
-				os.Exit(1) // This should never happen.
-			}
+		if *local || args.Loader != nil {
+			s, err = solver.Load(args.Loader, append(options, priors...)...)
 		} else {
-			s = solver.From(wordler.Dictionary)
+			s, err = solver.ForLength(args.WordLength, append([]wordlist.Option{lang.Option()}, priors...)...)
+		}
+		if err != nil {
+			count.solverFailures++
+			fmt.Printf("Failed to make a Solver: %v\n", err)
+			os.Exit(1) // This should never happen.
+		}
+		if *fibble {
+			s.Tolerate(1)
 		}
+		s.UseBook(book)
 
 		var guess, response string
 		var guesses []string
+		refused := make(map[string]bool) // guesses the puzzler refused under hard rules
 	OUTER: // Loop until we win, get an error, or run out of guesses.
 		for p.Guesses() > 0 {
-			if p.Words() != s.Remaining() {
+			// Fibble allows exactly one lie per response, the solver at most one.
+			if !*fibble && p.Words() != s.Remaining() {
 				fmt.Printf("  ERROR: %d Puzzler words != %d Solver words (continuing anyway)\n", p.Words(), s.Remaining())
 			}
 			debug(0, "  %d guesses and %d words remain.", p.Guesses(), p.Words())
@@ -104,31 +212,51 @@ func main() {
 				}
 				guesses = append(guesses, guess)
 				response, err = p.Guess(guess)
-				switch err {
+				switch {
+
+				// Expected behavior -- valid guess.
+				case err == nil:
+					break GUESS
 
 				// This should never happen given that puzzler and solver use
-				// the same dictionary.
-				case puzzler.InvalidGuessErr, puzzler.NotInDictionaryErr:
+				// the same dictionary, unless an opening book or tree says to
+				// guess a word that isn't in it.
+				case errors.Is(err, puzzler.NotInDictionaryErr):
 					fmt.Printf("  Invalid guess '%v': %v\n", guess, err)
 					count.invalidGuesses++
 					s.NotInWordle(guess)
 
+				// The word may still be the solution, so keep it, but don't
+				// guess it from the book or tree again. The solver's own
+				// guesses follow hard rules, so refusing one twice would only
+				// repeat.
+				case errors.Is(err, puzzler.InvalidGuessErr):
+					fmt.Printf("  Invalid guess '%v': %v\n", guess, err)
+					count.invalidGuesses++
+					if refused[guess] {
+						break OUTER
+					}
+					refused[guess] = true
+					s.Refused(guess)
+
 				// This should never happen; we should break out of OUTER before
 				// getting this error.
-				case puzzler.OutOfGuessesErr:
+				case errors.Is(err, puzzler.OutOfGuessesErr):
 					count.outOfGuesses++
 					break OUTER
 
 				// This should never happen; we should either run out of guesses
 				// or win first.
-				case puzzler.NoWordsRemainingErr:
+				case errors.Is(err, puzzler.NoWordsRemainingErr):
 					fmt.Println("  Uh oh, no words remaining in Puzzler!?")
 					count.noWordsRemaining++
 					break OUTER
 
-				// Expected behavior -- valid guess.
-				case nil:
-					break GUESS
+				// Any other error would only repeat, so give up on this game.
+				default:
+					fmt.Printf("  ERROR: guess '%v' --> %v\n", guess, err)
+					count.invalidGuesses++
+					break OUTER
 				}
 			}
 
@@ -156,8 +284,94 @@ func main() {
 
 	count.winningIteration /= float32(count.winners)
 	debug(-1, "Stats gathered: %#v", count)
-	fmt.Printf("I won %.2f%% of games played with an average of %.2f guesses.\n",
+	fmt.Printf(lang.T("I won %.2f%% of games played with an average of %.2f guesses.\n"),
+		float32(count.winners*100)/float32(count.iterations), count.winningIteration)
+}
+
+// multiStats are the results of multi-board games.
+type multiStats struct {
+	iterations, winners, boards, solvedBoards int
+	winningIteration                          float32
+}
+
+// simulateMulti plays iterations multi-board games, each against a solver
+// from newSolver, and reports how many the solver won.
+func simulateMulti(lang *language.Language, args *puzzler.Args, boards, iterations int, newSolver func() (*solver.Multi, error), clGuesses []string) {
+	count := multiStats{}
+	for i := 0; i < iterations; i++ {
+		count.iterations++
+		debug(-1, "Iteration %d/%d: ", i+1, iterations)
+		p, err := puzzler.NewMulti(args, boards)
+		if err != nil {
+			fmt.Printf("Failed to make a Puzzler: %v\n", err)
+			os.Exit(1) // This should never happen.
+		}
+		s, err := newSolver()
+		if err != nil {
+			fmt.Printf("Failed to make a Solver: %v\n", err)
+			os.Exit(1) // This should never happen.
+		}
+
+		var guesses []string
+	GAME: // Loop until we solve every board, get an error, or run out of guesses.
+		for p.Guesses() > 0 && !p.Solved() {
+			var guess string
+			if len(clGuesses) > 0 {
+				guess = clGuesses[0]
+				clGuesses = clGuesses[1:]
+			} else {
+				guess = s.Guess()
+			}
+			if guess == "" {
+				fmt.Println("  Uh oh, no words remaining in Solver!?")
+				break
+			}
+			guesses = append(guesses, guess)
+			responses, err := p.Guess(guess)
+			switch {
+			case err == nil:
+
+			// The puzzler doesn't count a guess it doesn't know, so guess
+			// again.
+			case errors.Is(err, puzzler.NotInDictionaryErr):
+				fmt.Printf("  Invalid guess '%v': %v\n", guess, err)
+				s.NotInWordle(guess)
+				continue
+
+			// Any other error would only repeat, so give up on this game.
+			default:
+				fmt.Printf("  ERROR: guess '%v' --> %v\n", guess, err)
+				break GAME
+			}
+			debug(1, "  '%v' --> %v", guess, strings.Join(responses, " "))
+			if err := s.React(guess, responses); err != nil {
+				fmt.Printf("  ERROR: guess '%v' --> %v\n", guess, err)
+			}
+		}
+
+		count.boards += boards
+		for b := 0; b < boards; b++ {
+			if p.IsSolved(b) {
+				count.solvedBoards++
+			}
+		}
+		if p.Solved() {
+			debug(-1, "  WINNER! Guesses: %v", strings.Join(guesses, ", "))
+			count.winners++
+			count.winningIteration += float32(args.Guesses - p.Guesses())
+		} else {
+			debug(-1, "  YOU LOSE!")
+			debug(-1, "  Guesses were: %v", strings.Join(guesses, ", "))
+		}
+		debug(-1, "  The solutions are %v.", strings.Join(p.GiveUp(), ", "))
+		debug(-1, "")
+	}
+
+	count.winningIteration /= float32(count.winners)
+	debug(-1, "Stats gathered: %#v", count)
+	fmt.Printf(lang.T("I won %.2f%% of games played with an average of %.2f guesses.\n"),
 		float32(count.winners*100)/float32(count.iterations), count.winningIteration)
+	fmt.Printf(lang.T("I solved %.2f%% of boards.\n"), float32(count.solvedBoards*100)/float32(count.boards))
 }
 
 // debug prints debug logs
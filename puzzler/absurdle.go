@@ -0,0 +1,143 @@
+package puzzler
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"wordler"
+	"wordler/wordlist"
+)
+
+// Absurdle is an adversarial puzzle with no fixed solution: it answers each
+// guess with the response shared by the most remaining words, so it only
+// loses when it has no choice.
+type Absurdle struct {
+	dict             *wordlist.WordList // full dictionary
+	remaining        *wordlist.WordList // words consistent with every response so far
+	remainingGuesses int                // how many guesses are left
+	hard             bool               // hard or easy rules?
+	rows             []wordler.Row      // guesses and responses, in order
+}
+
+// NewAbsurdle creates a new Absurdle puzzle, limiting allowed words as New
+// does. a.Solution is ignored.
+func NewAbsurdle(a *Args) (*Absurdle, error) {
+	if a == nil {
+		a = &Args{
+			Hard:       true,
+			WordLength: wordler.DEFAULT_WORD_LENGTH,
+			Guesses:    wordler.DEFAULT_GUESSES,
+		}
+	}
+	args := *a
+	args.Solution = ""
+	w, err := New(&args)
+	if err != nil {
+		return nil, err
+	}
+	return &Absurdle{
+		dict:             w.dict,
+		remaining:        w.solutions().Clone(),
+		remainingGuesses: a.Guesses,
+		hard:             a.Hard,
+	}, nil
+}
+
+// Guess the given word. The response is the one that leaves the most words
+// possible; among equally large groups of words, Absurdle avoids a win and then
+// prefers the response with the fewest CORRECT and then ELSEWHERE letters.
+func (a *Absurdle) Guess(g string) (string, error) {
+	if a == nil || a.remainingGuesses == 0 {
+		return "", OutOfGuessesErr
+	}
+	if err := a.validate(g); err != nil {
+		return "", err
+	}
+	a.remainingGuesses--
+
+	buckets := a.remaining.Buckets(g)
+	responses := make([]string, 0, len(buckets))
+	for response := range buckets {
+		responses = append(responses, response)
+	}
+	win := strings.Repeat(string(wordler.CORRECT), len([]rune(g)))
+	sort.Slice(responses, func(i, j int) bool {
+		ri, rj := responses[i], responses[j]
+		switch {
+		case buckets[ri] != buckets[rj]:
+			return buckets[ri] > buckets[rj]
+		case (ri == win) != (rj == win):
+			return rj == win
+		case strings.Count(ri, string(wordler.CORRECT)) != strings.Count(rj, string(wordler.CORRECT)):
+			return strings.Count(ri, string(wordler.CORRECT)) < strings.Count(rj, string(wordler.CORRECT))
+		case strings.Count(ri, string(wordler.ELSEWHERE)) != strings.Count(rj, string(wordler.ELSEWHERE)):
+			return strings.Count(ri, string(wordler.ELSEWHERE)) < strings.Count(rj, string(wordler.ELSEWHERE))
+		}
+		return ri < rj
+	})
+	response := responses[0]
+	debug("%d responses to '%s'; the largest, '%s', leaves %d words", len(responses), g, response, buckets[response])
+
+	a.remaining.KeepOnlyFunc(func(word string) bool {
+		return wordler.Score(g, word) == response
+	})
+	a.rows = append(a.rows, wordler.Row{Guess: g, Response: response})
+	return response, nil
+}
+
+// validate guess based on `hard` setting.
+func (a *Absurdle) validate(g string) error {
+	if !a.dict.Contains(g) {
+		return fmt.Errorf("'%s' %w", g, NotInDictionaryErr)
+	}
+	if a.Words() == 0 {
+		return NoWordsRemainingErr
+	}
+	if a.hard {
+		// Under hard rules, a guess must be able to be the solution.
+		for _, row := range a.rows {
+			if wordler.Score(row.Guess, g) != row.Response {
+				return InvalidGuessErr
+			}
+		}
+	}
+	return nil
+}
+
+// Guesses returns the number of guesses left.
+func (a *Absurdle) Guesses() int {
+	if a == nil {
+		return 0
+	}
+	return a.remainingGuesses
+}
+
+// Words returns the number of words Absurdle could still choose as the
+// solution.
+func (a *Absurdle) Words() int {
+	if a == nil {
+		return 0
+	}
+	return a.remaining.Length()
+}
+
+// History returns the guesses made so far and their responses, in order.
+func (a *Absurdle) History() []wordler.Row {
+	if a == nil {
+		return nil
+	}
+	return append([]wordler.Row{}, a.rows...)
+}
+
+// GiveUp: no more guesses are allowed, and Absurdle finally commits to one of
+// its remaining words as the solution.
+func (a *Absurdle) GiveUp() string {
+	if a == nil || a.Words() == 0 {
+		return ""
+	}
+	a.remainingGuesses = 0
+	word := a.remaining.Sorted()[0]
+	a.remaining.KeepOnlyFunc(func(w string) bool { return w == word })
+	return word
+}
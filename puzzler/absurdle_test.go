@@ -0,0 +1,104 @@
+package puzzler
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"wordler"
+	"wordler/wordlist"
+)
+
+func TestAbsurdle(t *testing.T) {
+	l := wordlist.NewReaderLoader(strings.NewReader("foo\nbar\nbam\nzap\nzoo\n"))
+	a, err := NewAbsurdle(&Args{WordLength: 3, Guesses: 6, Loader: l})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cases := []struct {
+		guess, response string
+		words           int
+	}{
+		// "___" leaves foo and zoo; the other responses leave one word each.
+		{"bar", "___", 2},
+		// "_++" and "+++" each leave one; Absurdle avoids the win.
+		{"zoo", "_++", 1},
+		// Absurdle is forced to lose.
+		{"foo", "+++", 1},
+	}
+	for _, c := range cases {
+		response, err := a.Guess(c.guess)
+		if err != nil {
+			t.Fatalf("%s: %v", c.guess, err)
+		}
+		if response != c.response || a.Words() != c.words {
+			t.Errorf("%s: want %s with %d words, got %s with %d", c.guess, c.response, c.words, response, a.Words())
+		}
+	}
+	if want, got := 3, len(a.History()); want != got {
+		t.Errorf("want %d rows, got %d", want, got)
+	}
+	if want, got := "foo", a.GiveUp(); want != got {
+		t.Errorf("want %q, got %q", want, got)
+	}
+	if _, err := a.Guess("foo"); !errors.Is(err, OutOfGuessesErr) {
+		t.Errorf("want %v, got %v", OutOfGuessesErr, err)
+	}
+}
+
+func TestAbsurdleFrequency(t *testing.T) {
+	// The remaining words keep the frequencies they were loaded with.
+	l := wordlist.NewReaderLoader(strings.NewReader("foo\nbar\nbam\nzap\nzoo\n"))
+	freq := wordlist.FrequencyOption{Freq: map[string]float64{"foo": 3, "zoo": 1, "bar": 1, "bam": 1, "zap": 1}}
+	a, err := NewAbsurdle(&Args{WordLength: 3, Guesses: 6, Loader: l, Options: []wordlist.Option{freq}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := a.Guess("bar"); err != nil {
+		t.Fatal(err)
+	}
+	if want, got := 3.0, a.remaining.Frequency("foo"); want != got {
+		t.Errorf("want frequency %v, got %v", want, got)
+	}
+}
+
+func TestAbsurdleHard(t *testing.T) {
+	l := wordlist.NewReaderLoader(strings.NewReader("foo\nbar\nbam\nzap\nzoo\n"))
+	a, err := NewAbsurdle(&Args{Hard: true, WordLength: 3, Guesses: 6, Loader: l})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := a.Guess("baz"); !errors.Is(err, NotInDictionaryErr) {
+		t.Errorf("want %v, got %v", NotInDictionaryErr, err)
+	}
+	if _, err := a.Guess("bar"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := a.Guess("bam"); !errors.Is(err, InvalidGuessErr) {
+		t.Errorf("want %v, got %v", InvalidGuessErr, err)
+	}
+}
+
+func TestAbsurdleResponses(t *testing.T) {
+	// Every response is consistent with every word Absurdle has left.
+	a, err := NewAbsurdle(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, guess := range []string{"crane", "moist"} {
+		if _, err := a.Guess(guess); err != nil {
+			t.Fatalf("%s: %v", guess, err)
+		}
+	}
+	if a.Words() < 2 {
+		t.Errorf("want Absurdle to keep several words, got %d", a.Words())
+	}
+	for _, word := range a.remaining.Sorted() {
+		for _, row := range a.History() {
+			if got := wordler.Score(row.Guess, word); got != row.Response {
+				t.Errorf("'%s' scores '%s' for '%s', but Absurdle said '%s'", row.Guess, got, word, row.Response)
+			}
+		}
+	}
+}
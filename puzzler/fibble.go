@@ -0,0 +1,131 @@
+package puzzler
+
+import (
+	"math/rand"
+	"strings"
+	"time"
+
+	"wordler"
+	"wordler/wordlist"
+)
+
+// FibbleGuesses is the number of guesses Fibble allows by default: more than
+// Wordle, to make up for the lies.
+const FibbleGuesses = 9
+
+// marks are the marks a response is made of.
+var marks = []rune{wordler.CORRECT, wordler.ELSEWHERE, wordler.NIL}
+
+// Fibble is a Wordle that lies: exactly one mark in every response is wrong,
+// and you aren't told which. Only a winning response tells the truth.
+type Fibble struct {
+	w         *Wordle            // the honest puzzle
+	remaining *wordlist.WordList // words consistent with every response, allowing for its lie
+	rows      []wordler.Row      // guesses and the responses given, lies and all
+	rng       *rand.Rand
+}
+
+// NewFibble creates a new Fibble puzzle, limiting allowed words as New does.
+// a.Hard is ignored: hard rules would give away which responses are true.
+func NewFibble(a *Args) (*Fibble, error) {
+	if a == nil {
+		a = &Args{
+			WordLength: wordler.DEFAULT_WORD_LENGTH,
+			Guesses:    FibbleGuesses,
+		}
+	}
+	args := *a
+	args.Hard = false
+	w, err := New(&args)
+	if err != nil {
+		return nil, err
+	}
+	return &Fibble{
+		w:         w,
+		remaining: w.solutions().Clone(),
+		rng:       rand.New(rand.NewSource(time.Now().UnixNano())),
+	}, nil
+}
+
+// Guess the given word. The response is the one Wordle would give, except
+// that one of its marks, chosen at random, is changed to one of the other two
+// marks. Winning responses aren't changed, and no other response is changed
+// to look like a win.
+func (f *Fibble) Guess(g string) (string, error) {
+	if f == nil {
+		return "", OutOfGuessesErr
+	}
+	truth, err := f.w.Guess(g)
+	if err != nil {
+		return "", err
+	}
+	win := strings.Repeat(string(wordler.CORRECT), len([]rune(g)))
+	response := truth
+	if truth != win {
+		response = f.lie(truth, win)
+	}
+	debug("'%s' --> '%s', but Fibble says '%s'", g, truth, response)
+
+	if response == win {
+		f.remaining = wordlist.New([]string{g})
+	} else {
+		f.remaining.KeepOnlyFunc(func(word string) bool {
+			return word != g && wordler.Mismatches(wordler.Score(g, word), response) == 1
+		})
+	}
+	f.rows = append(f.rows, wordler.Row{Guess: g, Response: response})
+	return response, nil
+}
+
+// lie returns truth with one mark changed, other than to win.
+func (f *Fibble) lie(truth, win string) string {
+	for {
+		r := []rune(truth)
+		i := f.rng.Intn(len(r))
+		others := make([]rune, 0, len(marks)-1)
+		for _, m := range marks {
+			if m != r[i] {
+				others = append(others, m)
+			}
+		}
+		r[i] = others[f.rng.Intn(len(others))]
+		if lie := string(r); lie != win {
+			return lie
+		}
+	}
+}
+
+// Guesses returns the number of guesses left.
+func (f *Fibble) Guesses() int {
+	if f == nil {
+		return 0
+	}
+	return f.w.Guesses()
+}
+
+// Words returns the number of words consistent with the responses so far,
+// given that each response except a win has exactly one wrong mark.
+func (f *Fibble) Words() int {
+	if f == nil {
+		return 0
+	}
+	return f.remaining.Length()
+}
+
+// History returns the guesses made so far and the responses given, in order.
+func (f *Fibble) History() []wordler.Row {
+	if f == nil {
+		return nil
+	}
+	return append([]wordler.Row{}, f.rows...)
+}
+
+// GiveUp: no more guesses are allowed and the solution is revealed.
+func (f *Fibble) GiveUp() string {
+	if f == nil {
+		return ""
+	}
+	word := f.w.GiveUp()
+	f.remaining = wordlist.New([]string{word})
+	return word
+}
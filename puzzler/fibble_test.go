@@ -0,0 +1,58 @@
+package puzzler
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"wordler"
+	"wordler/wordlist"
+)
+
+func TestFibble(t *testing.T) {
+	words := "foo\nbar\nbam\nzap\nzoo\nbaz\n"
+	for i := 0; i < 20; i++ {
+		f, err := NewFibble(&Args{Hard: true, WordLength: 3, Guesses: 6, Loader: wordlist.NewReaderLoader(strings.NewReader(words)), Solution: "bar"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := f.Guess("qux"); !errors.Is(err, NotInDictionaryErr) {
+			t.Errorf("want %v, got %v", NotInDictionaryErr, err)
+		}
+		// Hard rules are ignored, so inconsistent guesses are allowed.
+		for _, guess := range []string{"zoo", "foo", "bam"} {
+			response, err := f.Guess(guess)
+			if err != nil {
+				t.Fatalf("%s: %v", guess, err)
+			}
+			if got := wordler.Mismatches(response, wordler.Score(guess, "bar")); got != 1 {
+				t.Errorf("%s --> %s: want 1 lie, got %d", guess, response, got)
+			}
+			if response == "+++" {
+				t.Errorf("%s: want no false win", guess)
+			}
+		}
+		if f.Words() == 0 {
+			t.Errorf("want 'bar' still possible, got no words")
+		}
+		if response, err := f.Guess("bar"); err != nil || response != "+++" {
+			t.Errorf("want an honest win, got %q, %v", response, err)
+		}
+		if f.Words() != 1 || len(f.History()) != 4 || f.Guesses() != 2 {
+			t.Errorf("want 1 word, 4 rows and 2 guesses, got %d, %d and %d", f.Words(), len(f.History()), f.Guesses())
+		}
+		if want, got := "bar", f.GiveUp(); want != got {
+			t.Errorf("want %q, got %q", want, got)
+		}
+	}
+}
+
+func TestFibbleDefaults(t *testing.T) {
+	f, err := NewFibble(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, got := FibbleGuesses, f.Guesses(); want != got {
+		t.Errorf("want %d guesses, got %d", want, got)
+	}
+}
@@ -4,10 +4,15 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"regexp"
 	"strings"
 
 	"wordler"
+	"wordler/analyzer"
+	"wordler/language"
 	"wordler/puzzler"
+	"wordler/solver"
+	"wordler/wordlist"
 )
 
 func main() {
@@ -15,25 +20,75 @@ func main() {
 	flag.BoolVar(&args.Hard, "hard", true, "use hard rules: 'Any revealed hints must be used in subsequent guesses'")
 	flag.IntVar(&args.WordLength, "length", wordler.DEFAULT_WORD_LENGTH, "word length")
 	flag.IntVar(&args.Guesses, "guesses", wordler.DEFAULT_GUESSES, "number of guesses allowed")
-	flag.StringVar(&args.Solution, "solution", "", "puzzler will use the specified solution")
+	flag.StringVar(&args.Solution, "solution", "", "puzzler will use the specified solution; with --boards, one per board, separated by commas")
 	localDictionary := flag.Bool("local_dictionary", false, "load local dictionary in place of Wordle dictionary")
+	dictionary := flag.String("dictionary", "", "load words from this file, one per line (optionally gzip-compressed), in place of Wordle dictionary")
+	analyze := flag.Bool("analyze", false, "rate each of your guesses when the game is over")
+	code := flag.String("lang", language.DEFAULT, fmt.Sprintf("language: one of %s", strings.Join(language.Codes(), ", ")))
+	boards := flag.Int("boards", 1, "number of boards, each with its own hidden word, that share the guesses: 2 for Dordle, 4 for Quordle, 8 for Octordle")
+	fibble := flag.Bool("fibble", false, "play Fibble: one mark in every response, except a win, is a lie")
 	flag.Parse()
 
-	fmt.Println("I'm a wordle puzzle! You make guesses, I'll score them.")
-	if *localDictionary {
+	guessesSet := false
+	flag.Visit(func(f *flag.Flag) { guessesSet = guessesSet || f.Name == "guesses" })
+	if *fibble && !guessesSet {
+		args.Guesses = puzzler.FibbleGuesses
+	}
+	if *boards > 1 {
+		if !guessesSet {
+			args.Guesses = puzzler.MultiGuesses(*boards)
+		}
+		if args.Solution != "" {
+			args.Solutions = strings.Split(args.Solution, ",")
+		}
+	}
+
+	lang, err := language.Get(*code)
+	if err != nil {
+		fmt.Println("ERROR: ", err)
+		os.Exit(2)
+	}
+	if *fibble && *boards > 1 {
+		fmt.Println("ERROR: --fibble cannot be used with --boards")
+		os.Exit(2)
+	}
+	args.Options = append(args.Options, lang.Option())
+
+	fmt.Println(lang.T("I'm a wordle puzzle! You make guesses, I'll score them."))
+	if *dictionary != "" {
+		args.Loader = wordlist.NewFileLoader(*dictionary)
+		fmt.Printf(lang.T("I only allow %d-letter words found in %s.\n"), args.WordLength, *dictionary)
+	} else if *localDictionary {
 		args.Dictionary = puzzler.LocalDictionary
-		fmt.Printf("I only allow %d-letter words found in the local dictionary.\n", args.WordLength)
-	}
-	fmt.Printf("I'll use '%c' for \"right letter in the right place\"\n", wordler.CORRECT)
-	fmt.Printf("I'll use '%c' for \"right letter in the wrong place\"\n", wordler.ELSEWHERE)
-	fmt.Printf("I'll use '%c' for \"letter not in the word\"\n", wordler.NIL)
-	fmt.Println("I'll respond with the letter 'n' by itself if your guess isn't in thedictionary.")
-	fmt.Printf("You've got %d guesses.\n", args.Guesses)
-	fmt.Println("Ready? Here we go!")
+		fmt.Printf(lang.T("I only allow %d-letter words found in the local dictionary.\n"), args.WordLength)
+	} else if args.Loader = lang.Loader(); args.Loader != nil {
+		fmt.Printf(lang.T("I only allow %d-letter words in %s.\n"), args.WordLength, lang.Name)
+	}
+	fmt.Printf(lang.T("I'll use '%c' for \"right letter in the right place\"\n"), wordler.CORRECT)
+	fmt.Printf(lang.T("I'll use '%c' for \"right letter in the wrong place\"\n"), wordler.ELSEWHERE)
+	fmt.Printf(lang.T("I'll use '%c' for \"letter not in the word\"\n"), wordler.NIL)
+	fmt.Println(lang.T("I'll respond with the letter 'n' by itself if your guess isn't in the dictionary."))
+	if *boards > 1 {
+		fmt.Printf(lang.T("You're playing %d boards at once; each guess counts on every board.\n"), *boards)
+	}
+	if *fibble {
+		fmt.Println(lang.T("I'm Fibble: I lie about exactly one letter in every response, unless you win."))
+	}
+	fmt.Printf(lang.T("You've got %d guesses.\n"), args.Guesses)
+	fmt.Println(lang.T("Ready? Here we go!"))
 	fmt.Println()
 
-	p, err := puzzler.New(args)
+	if *boards > 1 {
+		playMulti(lang, args, *boards)
+		return
+	}
 
+	var p puzzler.Puzzler
+	if *fibble {
+		p, err = puzzler.NewFibble(args)
+	} else {
+		p, err = puzzler.New(args)
+	}
 	if err != nil {
 		fmt.Printf("Failed to make a Puzzler: %v\n", err)
 		os.Exit(2)
@@ -41,39 +96,121 @@ func main() {
 
 	winningResponse := strings.Repeat(string(wordler.CORRECT), args.WordLength)
 	for p.Guesses() > 0 {
-		fmt.Printf("%d guesses and %d words remain.\n", p.Guesses(), p.Words())
+		fmt.Printf(lang.T("%d guesses and %d words remain.\n"), p.Guesses(), p.Words())
 		var guess, response string
 
 	GUESS:
 		for {
-			fmt.Print("Your guess? ")
+			fmt.Print(lang.T("Your guess? "))
 			fmt.Scan(&guess)
+			if err := lang.Validate(guess); err != nil {
+				fmt.Println(lang.T("Invalid guess: "), err)
+				continue
+			}
 
 			var err error
 			response, err = p.Guess(guess)
 			switch err {
 			case puzzler.InvalidGuessErr, puzzler.NotInDictionaryErr:
-				fmt.Println("Invalid guess: ", err)
+				fmt.Println(lang.T("Invalid guess: "), err)
 			case puzzler.OutOfGuessesErr:
 				break GUESS
 			case puzzler.NoWordsRemainingErr:
-				fmt.Println("Uh oh, no words remaining!?")
+				fmt.Println(lang.T("Uh oh, no words remaining!?"))
 				break GUESS
 			case nil:
 				break GUESS
 			}
 		}
 		if response == winningResponse {
-			fmt.Println("YOU WIN!")
+			fmt.Println(lang.T("YOU WIN!"))
 			break
 		} else {
-			fmt.Println("Response:  ", response)
+			fmt.Println(lang.T("Response:  "), response)
+			fmt.Println()
+			fmt.Print(lang.ShowKeyboard(p.History()))
 			fmt.Println()
 		}
 	}
 
 	if p.Guesses() == 0 {
-		fmt.Println("YOU LOSE!")
+		fmt.Println(lang.T("YOU LOSE!"))
+	}
+	fmt.Printf(lang.T("The solution is '%v'.\n"), p.GiveUp())
+
+	if *analyze {
+		fmt.Println()
+		fmt.Println(lang.T("Here's how you did:"))
+		var s *solver.Solver
+		if *localDictionary || args.Loader != nil {
+			s, err = solver.Load(args.Loader, wordlist.KeepOnlyOption{Exp: regexp.MustCompile(fmt.Sprintf(`^\p{Ll}{%d}$`, args.WordLength))}, lang.Option())
+		} else {
+			s, err = solver.ForLength(args.WordLength, lang.Option())
+		}
+		if err != nil {
+			fmt.Printf("Failed to make a Solver: %v\n", err)
+			os.Exit(2)
+		}
+		turns, err := analyzer.Analyze(s, p.History())
+		analyzer.Report(os.Stdout, turns)
+		if err != nil {
+			fmt.Println("ERROR: ", err)
+		}
+	}
+}
+
+// playMulti plays a game with the given number of boards.
+func playMulti(lang *language.Language, args *puzzler.Args, boards int) {
+	m, err := puzzler.NewMulti(args, boards)
+	if err != nil {
+		fmt.Printf("Failed to make a Puzzler: %v\n", err)
+		os.Exit(2)
+	}
+
+	for m.Guesses() > 0 && !m.Solved() {
+		var unsolved []string
+		for i := 0; i < m.Boards(); i++ {
+			if !m.IsSolved(i) {
+				unsolved = append(unsolved, fmt.Sprint(m.Board(i).Words()))
+			}
+		}
+		fmt.Printf(lang.T("%d guesses remain; words remaining on unsolved boards: %s.\n"), m.Guesses(), strings.Join(unsolved, ", "))
+
+		var guess string
+		var responses []string
+		for {
+			fmt.Print(lang.T("Your guess? "))
+			if _, err := fmt.Scan(&guess); err != nil {
+				fmt.Println()
+				os.Exit(0)
+			}
+			if err := lang.Validate(guess); err != nil {
+				fmt.Println(lang.T("Invalid guess: "), err)
+				continue
+			}
+			if responses, err = m.Guess(guess); err != nil {
+				fmt.Println(lang.T("Invalid guess: "), err)
+				continue
+			}
+			break
+		}
+		for i, response := range responses {
+			switch {
+			case response == "":
+				fmt.Printf(lang.T("Board %d: solved\n"), i+1)
+			case m.IsSolved(i):
+				fmt.Printf(lang.T("Board %d: %s  SOLVED!\n"), i+1, response)
+			default:
+				fmt.Printf(lang.T("Board %d: %s\n"), i+1, response)
+			}
+		}
+		fmt.Println()
+	}
+
+	if m.Solved() {
+		fmt.Println(lang.T("YOU WIN!"))
+	} else {
+		fmt.Println(lang.T("YOU LOSE!"))
 	}
-	fmt.Printf("The solution is '%v'.\n", p.GiveUp())
+	fmt.Printf(lang.T("The solutions are %s.\n"), strings.Join(m.GiveUp(), ", "))
 }
@@ -0,0 +1,169 @@
+package puzzler
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"wordler"
+)
+
+// AllSolvedErr is returned by Multi.Guess once every board has been solved.
+var AllSolvedErr = errors.New("all boards solved")
+
+// Multi is a multi-board puzzle, like Dordle, Quordle or Octordle: every guess
+// is scored against each board's hidden word, boards are solved independently
+// and the boards share one budget of guesses. Hard rules don't apply.
+type Multi struct {
+	boards           []*Wordle // one per hidden word
+	solved           []bool    // solved[i] is true once boards[i] has been solved
+	remainingGuesses int       // how many guesses are left, shared by the boards
+}
+
+// MultiGuesses returns the usual number of guesses for a game with the given
+// number of boards: 6 for 1, 7 for 2, 9 for 4 and 13 for 8.
+func MultiGuesses(boards int) int {
+	return boards + 5
+}
+
+// NewMulti creates a new multi-board puzzle with the given number of boards,
+// limiting allowed words as New does. a.Solutions, if set, gives the solution
+// of each board; otherwise each board gets a different random word. a.Hard is
+// ignored.
+func NewMulti(a *Args, boards int) (*Multi, error) {
+	if boards < 1 {
+		return nil, fmt.Errorf("invalid args: want at least 1 board, got %d", boards)
+	}
+	if a == nil {
+		a = &Args{
+			WordLength: wordler.DEFAULT_WORD_LENGTH,
+			Guesses:    MultiGuesses(boards),
+		}
+	}
+	if len(a.Solutions) > 0 && len(a.Solutions) != boards {
+		return nil, fmt.Errorf("invalid args: want %d solutions, got %d", boards, len(a.Solutions))
+	}
+
+	args := *a
+	args.Hard = false
+	args.Solution = ""
+	first, err := New(&args)
+	if err != nil {
+		return nil, err
+	}
+	if first.solutions().Length() < boards {
+		return nil, fmt.Errorf("invalid args: only %d words for %d boards", first.solutions().Length(), boards)
+	}
+
+	m := &Multi{solved: make([]bool, boards), remainingGuesses: a.Guesses}
+	unused := first.solutions().Clone() // words no board has been given yet
+	for i := 0; i < boards; i++ {
+		w := &Wordle{
+			dict:             first.dict,
+			answers:          first.answers,
+			remaining:        first.dict.Clone(),
+			remainingGuesses: a.Guesses,
+		}
+		if len(a.Solutions) > 0 {
+			if err := w.validate(a.Solutions[i]); err != nil {
+				return nil, err
+			}
+			w.word = a.Solutions[i]
+			if w.answers != nil && !w.answers.Contains(w.word) {
+				w.answers = nil
+			}
+		} else {
+			w.word = unused.Random()
+			word := w.word
+			unused.KeepOnlyFunc(func(w string) bool { return w != word })
+		}
+		m.boards = append(m.boards, w)
+	}
+	return m, nil
+}
+
+// Guess the given word on every board. The returned responses hold, for each
+// board, the response to the guess as Wordle.Guess returns it, or the empty
+// string if the board was already solved.
+func (m *Multi) Guess(g string) ([]string, error) {
+	switch {
+	case m == nil || m.remainingGuesses == 0:
+		return nil, OutOfGuessesErr
+	case m.Solved():
+		return nil, AllSolvedErr
+	}
+	if !m.boards[0].dict.Contains(g) {
+		return nil, fmt.Errorf("'%s' %w", g, NotInDictionaryErr)
+	}
+	m.remainingGuesses--
+
+	responses := make([]string, len(m.boards))
+	for i, w := range m.boards {
+		if m.solved[i] {
+			continue
+		}
+		response, err := w.Guess(g)
+		if err != nil {
+			return nil, fmt.Errorf("board %d: %w", i+1, err)
+		}
+		responses[i] = response
+		m.solved[i] = response == strings.Repeat(string(wordler.CORRECT), len(response))
+	}
+	return responses, nil
+}
+
+// Boards returns the number of boards.
+func (m *Multi) Boards() int {
+	if m == nil {
+		return 0
+	}
+	return len(m.boards)
+}
+
+// Board returns board i, counting from 0, as a Wordle; its Words and History
+// are those of the board.
+func (m *Multi) Board(i int) *Wordle {
+	if m == nil || i < 0 || i >= len(m.boards) {
+		return nil
+	}
+	return m.boards[i]
+}
+
+// IsSolved returns true if board i, counting from 0, has been solved.
+func (m *Multi) IsSolved(i int) bool {
+	return m != nil && i >= 0 && i < len(m.solved) && m.solved[i]
+}
+
+// Solved returns true if every board has been solved.
+func (m *Multi) Solved() bool {
+	if m == nil {
+		return false
+	}
+	for _, solved := range m.solved {
+		if !solved {
+			return false
+		}
+	}
+	return true
+}
+
+// Guesses returns the number of guesses left.
+func (m *Multi) Guesses() int {
+	if m == nil {
+		return 0
+	}
+	return m.remainingGuesses
+}
+
+// GiveUp: no more guesses are allowed and the solutions are revealed.
+func (m *Multi) GiveUp() []string {
+	if m == nil {
+		return nil
+	}
+	m.remainingGuesses = 0
+	var solutions []string
+	for _, w := range m.boards {
+		solutions = append(solutions, w.GiveUp())
+	}
+	return solutions
+}
@@ -0,0 +1,126 @@
+package puzzler
+
+import (
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"wordler/wordlist"
+)
+
+func TestMultiGuesses(t *testing.T) {
+	for boards, want := range map[int]int{1: 6, 2: 7, 4: 9, 8: 13} {
+		if got := MultiGuesses(boards); got != want {
+			t.Errorf("%d boards: want %d guesses, got %d", boards, want, got)
+		}
+	}
+}
+
+func TestNewMulti(t *testing.T) {
+	m, err := NewMulti(nil, 8)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if m.Boards() != 8 || m.Guesses() != 13 {
+		t.Errorf("want 8 boards and 13 guesses, got %d and %d", m.Boards(), m.Guesses())
+	}
+	seen := make(map[string]bool)
+	for _, word := range m.GiveUp() {
+		if seen[word] {
+			t.Errorf("'%s' is the solution to two boards", word)
+		}
+		seen[word] = true
+	}
+
+	l := wordlist.NewReaderLoader(strings.NewReader("foo\nbar\n"))
+	for _, c := range []struct {
+		args   *Args
+		boards int
+	}{
+		{&Args{WordLength: 3, Guesses: 6, Loader: l}, 0},
+		{&Args{WordLength: 3, Guesses: 6, Loader: l}, 3},
+		{&Args{WordLength: 3, Guesses: 6, Loader: l, Solutions: []string{"foo"}}, 2},
+		{&Args{WordLength: 3, Guesses: 6, Loader: l, Solutions: []string{"foo", "baz"}}, 2},
+	} {
+		if m, err := NewMulti(c.args, c.boards); err == nil {
+			t.Errorf("%d boards, %#v: want error, got %v", c.boards, c.args, m.GiveUp())
+		}
+	}
+
+	// Boards get different words even when only one word can be drawn by
+	// its frequency.
+	freq := wordlist.FrequencyOption{Freq: map[string]float64{"foo": 1, "bar": 0}}
+	l = wordlist.NewReaderLoader(strings.NewReader("foo\nbar\n"))
+	m, err = NewMulti(&Args{WordLength: 3, Guesses: 6, Loader: l, Options: []wordlist.Option{freq}}, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, got := []string{"foo", "bar"}, m.GiveUp(); !reflect.DeepEqual(want, got) {
+		t.Errorf("want %v, got %v", want, got)
+	}
+}
+
+func TestMulti(t *testing.T) {
+	l := wordlist.NewReaderLoader(strings.NewReader("foo\nbar\nbam\nzap\n"))
+	m, err := NewMulti(&Args{WordLength: 3, Guesses: 3, Loader: l, Solutions: []string{"bar", "zap"}}, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := m.Guess("baz"); !errors.Is(err, NotInDictionaryErr) {
+		t.Errorf("want %v, got %v", NotInDictionaryErr, err)
+	}
+
+	cases := []struct {
+		guess     string
+		responses []string
+		solved    []bool
+		err       error
+	}{
+		{"bam", []string{"++_", "_+_"}, []bool{false, false}, nil},
+		{"bar", []string{"+++", "_+_"}, []bool{true, false}, nil},
+		{"zap", []string{"", "+++"}, []bool{true, true}, nil},
+		{"foo", nil, []bool{true, true}, OutOfGuessesErr},
+	}
+	for _, c := range cases {
+		responses, err := m.Guess(c.guess)
+		if !errors.Is(err, c.err) {
+			t.Errorf("%s: want %v, got %v", c.guess, c.err, err)
+		}
+		if !reflect.DeepEqual(responses, c.responses) {
+			t.Errorf("%s: want %q, got %q", c.guess, c.responses, responses)
+		}
+		for i, want := range c.solved {
+			if got := m.IsSolved(i); got != want {
+				t.Errorf("%s: board %d: want solved %t, got %t", c.guess, i+1, want, got)
+			}
+		}
+	}
+	if !m.Solved() || m.Guesses() != 0 {
+		t.Errorf("want all solved with no guesses left, got %t and %d", m.Solved(), m.Guesses())
+	}
+	if want, got := 2, len(m.Board(0).History()); want != got {
+		t.Errorf("board 1: want %d rows, got %d", want, got)
+	}
+	if want, got := 3, len(m.Board(1).History()); want != got {
+		t.Errorf("board 2: want %d rows, got %d", want, got)
+	}
+}
+
+func TestMultiAllSolved(t *testing.T) {
+	l := wordlist.NewReaderLoader(strings.NewReader("foo\nbar\n"))
+	m, err := NewMulti(&Args{WordLength: 3, Guesses: 7, Loader: l, Solutions: []string{"foo", "foo"}}, 2)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := m.Guess("foo"); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := m.Guess("bar"); !errors.Is(err, AllSolvedErr) {
+		t.Errorf("want %v, got %v", AllSolvedErr, err)
+	}
+	if m.Guesses() != 6 {
+		t.Errorf("want 6 guesses left, got %d", m.Guesses())
+	}
+}
@@ -4,7 +4,6 @@ import (
 	"errors"
 	"fmt"
 	"regexp"
-	"strings"
 
 	"wordler"
 	"wordler/wordlist"
@@ -19,13 +18,31 @@ const (
 	LocalDictionary = 1
 )
 
+// Puzzler is a puzzle that scores guesses; Wordle, Absurdle and Fibble are
+// Puzzlers.
+type Puzzler interface {
+	// Guess scores the given word, returning a string of wordler.CORRECT,
+	// wordler.ELSEWHERE and wordler.NIL.
+	Guess(g string) (string, error)
+	// Guesses returns the number of guesses left.
+	Guesses() int
+	// Words returns the number of possible solutions remaining.
+	Words() int
+	// History returns the guesses made so far and their responses, in order.
+	History() []wordler.Row
+	// GiveUp ends the game and reveals a solution.
+	GiveUp() string
+}
+
 // Wordle is a Wordle puzzle.
 type Wordle struct {
 	dict             *wordlist.WordList // full dictionary
+	answers          *wordlist.WordList // words the answer is chosen from; nil if any word in dict
 	remaining        *wordlist.WordList // words remaining
 	word             string             // the answer
 	remainingGuesses int                // how many guesses are left
 	hard             bool               // hard or easy rules?
+	rows             []wordler.Row      // guesses and responses, in order
 }
 
 // Args are used to construct a new Wordle puzzle.
@@ -33,8 +50,12 @@ type Args struct {
 	Dictionary          Dictionary // dictionary to use; either Wordle or Local
 	Hard                bool       // hard rules
 	WordLength, Guesses int
-	Solution            string // create a puzzler with this solution; otherwise a random word is chosen
+	Solution            string   // create a puzzler with this solution; otherwise a random word is chosen
+	Solutions           []string // for NewMulti, the solution of each board
 	Options             []wordlist.Option
+	// Loader, if set, loads the dictionary in place of Dictionary; words are
+	// limited to WordLength letters, as with LocalDictionary.
+	Loader wordlist.DictionaryLoader
 }
 
 var (
@@ -58,26 +79,42 @@ func New(a *Args) (*Wordle, error) {
 
 	w := &Wordle{remainingGuesses: a.Guesses, hard: a.Hard}
 	var err error
-	switch a.Dictionary {
+	dictionary := a.Dictionary
+	if a.Loader != nil {
+		dictionary = LocalDictionary
+	}
+	switch dictionary {
 	case WordleDictionary:
 		switch a.WordLength {
 		case wordler.DEFAULT_WORD_LENGTH, 0:
-			// Allowed
+			w.dict = wordlist.New(wordler.Dictionary, a.Options...)
 		default:
-			return nil, fmt.Errorf("invalid args: cannot specify word length != %d when using Wordle dictionary", wordler.DEFAULT_WORD_LENGTH)
+			// Other lengths use the bundled lists, which keep answers
+			// apart from the words that are only allowed as guesses.
+			answers, err := wordlist.NewAnswerLoader(a.WordLength)
+			if err != nil {
+				return nil, fmt.Errorf("invalid args: %w", err)
+			}
+			guesses, err := wordlist.NewGuessLoader(a.WordLength)
+			if err != nil {
+				return nil, fmt.Errorf("invalid args: %w", err)
+			}
+			if w.dict, err = guesses.Load(a.Options...); err != nil {
+				return nil, err
+			}
+			if w.answers, err = answers.Load(a.Options...); err != nil {
+				return nil, err
+			}
 		}
-		w.dict = wordlist.New(wordler.Dictionary, a.Options...)
-		w.remaining = wordlist.New(wordler.Dictionary, a.Options...)
+		w.remaining = w.dict.Clone()
 
 	case LocalDictionary:
-		a.Options = append(a.Options, wordlist.KeepOnlyOption{Exp: regexp.MustCompile(fmt.Sprintf("^[a-z]{%d}$", a.WordLength))})
+		options := append(a.Options[:len(a.Options):len(a.Options)], wordlist.KeepOnlyOption{Exp: regexp.MustCompile(fmt.Sprintf(`^\p{Ll}{%d}$`, a.WordLength))})
 
-		if w.dict, err = wordlist.NewDictionary(a.Options...); err != nil {
-			return nil, err
-		}
-		if w.remaining, err = wordlist.NewDictionary(a.Options...); err != nil {
+		if w.dict, err = wordlist.LoadDictionary(a.Loader, options...); err != nil {
 			return nil, err
 		}
+		w.remaining = w.dict.Clone()
 
 	default:
 		return nil, errors.New("invalid dictionary option")
@@ -92,8 +129,12 @@ func New(a *Args) (*Wordle, error) {
 		} else {
 			return nil, err
 		}
+		if w.answers != nil && !w.answers.Contains(w.word) {
+			// A solution that isn't an answer makes every word possible.
+			w.answers = nil
+		}
 	} else {
-		w.word = w.dict.Random()
+		w.word = w.solutions().Random()
 	}
 	return w, nil
 }
@@ -110,71 +151,74 @@ func (w *Wordle) Guess(g string) (string, error) {
 	}
 	w.remainingGuesses--
 
-	word := w.word
+	word, guess := []rune(w.word), []rune(g)
 
 	// To score the guess, we:
 	// - first score any letters as CORRECT
 	// - next score letters as ELSEWHERE
 	// - remaining letters are scored NIL
-	// As we score, we transform guess g into the returned score.
+	// As we score, we transform guess into the returned score.
 	// As we score, we delete letters from word to prevent double-scoring.
-	lettersFound := make(map[byte]bool)
+	// Letters are runes, so words needn't be ASCII.
+	lettersFound := make(map[rune]int) // how many of each letter we've scored
 
 	// First score all the letters in the CORRECT place.
-	for i, b := range g {
-		c := byte(b)
+	for i, c := range guess {
 		if word[i] == c {
-			g = g[:i] + string(wordler.CORRECT) + g[i+1:]
-			word = word[:i] + string(wordler.CORRECT) + word[i+1:] // prevent additional matches on this letter
-			w.remaining.KeepOnly(regexp.MustCompile(fmt.Sprintf("^%s%s", strings.Repeat(".", i), string(c))))
-			lettersFound[c] = true
+			guess[i] = wordler.CORRECT
+			word[i] = wordler.CORRECT // prevent additional matches on this letter
+			w.remaining.KeepOnlyAt(i, c)
+			lettersFound[c]++
 		}
 	}
-	debug("after scoring %c, guess is %v, word is %v", wordler.CORRECT, g, word)
+	debug("after scoring %c, guess is %v, word is %v", wordler.CORRECT, string(guess), string(word))
 
 	// Now score all the letters that appear ELSEWHERE in word.
-	for i, b := range g { // i is the letter's position in g
-		c := byte(b)
-		if c == wordler.CORRECT {
+	for i, c := range []rune(g) { // i is the letter's position in guess
+		if guess[i] == wordler.CORRECT {
 			continue
 		}
 
-		for j, b := range word { // j is the position we are comparing in word
-			l := byte(b)
+		for j, l := range word { // j is the position we are comparing in word
 			if j == i || l == wordler.CORRECT || l == wordler.ELSEWHERE {
 				continue
 			}
 			if l == c { // if l in word matches c in guess
-				g = g[:i] + string(wordler.ELSEWHERE) + g[i+1:]
-				word = word[:j] + string(wordler.ELSEWHERE) + word[j+1:] // prevent additional matches on this letter
-				debug("keeping only words containing '%c'", c)
-				w.remaining.KeepOnly(regexp.MustCompile(string(c)))
+				guess[i] = wordler.ELSEWHERE
+				word[j] = wordler.ELSEWHERE // prevent additional matches on this letter
+				lettersFound[c]++
+				debug("keeping only words containing '%c' at least %d times", c, lettersFound[c])
+				w.remaining.KeepOnlyContaining(c, lettersFound[c])
 				debug("%d words left.", w.remaining.Length())
-				pattern := fmt.Sprintf("^%s%s", strings.Repeat(".", i), string(c))
-				debug("deleting all words with '%c' as char %d by using regexp '%v'", c, i+1, pattern)
-				w.remaining.Delete(regexp.MustCompile(pattern))
+				debug("deleting all words with '%c' as char %d", c, i+1)
+				w.remaining.DeleteAt(i, c)
 				debug("%d words left.", w.remaining.Length())
-				lettersFound[c] = true
 				break
 			}
 		}
 
-		if g[i] != wordler.ELSEWHERE {
-			g = g[:i] + string(wordler.NIL) + g[i+1:]
-			if !lettersFound[c] {
+		if guess[i] != wordler.ELSEWHERE {
+			guess[i] = wordler.NIL
+			if lettersFound[c] == 0 {
 				debug("deleting all words containing '%c'", c)
-				w.remaining.Delete(regexp.MustCompile(string(c)))
+				w.remaining.DeleteContaining(c, 1)
 			} else {
-				pattern := fmt.Sprintf("^%s%s", strings.Repeat(".", i), string(c))
-				debug("deleting all words with '%c' as char %d by using regexp '%v'", c, i+1, pattern)
-				w.remaining.Delete(regexp.MustCompile(pattern))
+				// We've already scored every c in word, so word has
+				// exactly lettersFound[c] of them. The solver draws the
+				// same conclusion from the response, and Words() must
+				// keep agreeing with its Remaining().
+				debug("deleting all words with '%c' as char %d or more than %d times", c, i+1, lettersFound[c])
+				w.remaining.DeleteAt(i, c)
+				w.remaining.DeleteContaining(c, lettersFound[c]+1)
 			}
 			debug("%d words left.", w.remaining.Length())
 		}
 	}
 
-	// g is now a combination of CORRECT, ELSEWHERE, and NIL
-	return g, nil
+	// guess is now a combination of CORRECT, ELSEWHERE, and NIL
+	response := string(guess)
+	w.rows = append(w.rows, wordler.Row{Guess: g, Response: response})
+	return response, nil
 }
 
 // validate guess based on `hard` setting.
@@ -201,12 +245,33 @@ func (w *Wordle) Guesses() int {
 
 // Words returns the number of words remaining. The wordle puzzle tracks words
 // remaining assuming you apply all guess responses correctly and only guess
-// using `hard` rules.
+// using `hard` rules. Only words that may be the answer are counted.
 func (w *Wordle) Words() int {
 	if w == nil {
 		return 0
 	}
-	return w.remaining.Length()
+	if w.answers == nil {
+		return w.remaining.Length()
+	}
+	possible := w.answers.Clone()
+	possible.KeepOnlyFunc(w.remaining.Contains)
+	return possible.Length()
+}
+
+// solutions returns the words the answer may be.
+func (w *Wordle) solutions() *wordlist.WordList {
+	if w.answers != nil {
+		return w.answers
+	}
+	return w.dict
+}
+
+// History returns the guesses made so far and their responses, in order.
+func (w *Wordle) History() []wordler.Row {
+	if w == nil {
+		return nil
+	}
+	return append([]wordler.Row{}, w.rows...)
 }
 
 // GiveUp: no more guesses are allowed and the solution is revealed.
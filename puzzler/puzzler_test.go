@@ -3,12 +3,20 @@ package puzzler
 import (
 	"errors"
 	"fmt"
+	"reflect"
+	"strings"
 	"testing"
 
 	"wordler"
 	"wordler/wordlist"
 )
 
+var (
+	_ Puzzler = (*Wordle)(nil)
+	_ Puzzler = (*Absurdle)(nil)
+	_ Puzzler = (*Fibble)(nil)
+)
+
 type fakeLoader struct {
 	words []string // words to load
 	err   error    // optional error for testing error paths
@@ -51,6 +59,57 @@ func TestNew(t *testing.T) {
 	}
 }
 
+func TestLoader(t *testing.T) {
+	l := wordlist.NewReaderLoader(strings.NewReader("foo\nbar\nbars\nBam\n"))
+	p, err := New(&Args{Hard: true, WordLength: 3, Guesses: 6, Loader: l})
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+	if want := wordlist.New([]string{"foo", "bar"}); !want.Equals(p.dict) {
+		t.Errorf("want %#v, got %#v", want, p.dict)
+	}
+}
+
+func TestBundledLengths(t *testing.T) {
+	for _, length := range []int{4, 6, 7} {
+		p, err := New(&Args{WordLength: length, Guesses: 6})
+		if err != nil {
+			t.Fatalf("%d letters: error: %v", length, err)
+		}
+		if !p.answers.Contains(p.word) {
+			t.Errorf("%d letters: answer '%s' is not in the bundled answers", length, p.word)
+		}
+		if want, got := p.answers.Length(), p.Words(); want != got {
+			t.Errorf("%d letters: want %d words, got %d", length, want, got)
+		}
+		if p.dict.Length() <= p.answers.Length() {
+			t.Errorf("%d letters: want more guesses than answers, got %d and %d", length, p.dict.Length(), p.answers.Length())
+		}
+	}
+
+	// Any allowed word may be guessed, but Words only counts answers.
+	p, err := New(&Args{WordLength: 6, Guesses: 6, Solution: "garden"})
+	if err != nil {
+		t.Fatalf("error: %v", err)
+	}
+	if _, err := p.Guess("zinnia"); err != nil {
+		t.Errorf("error: %v", err)
+	}
+	want := 0
+	for _, word := range p.remaining.Sorted() {
+		if p.answers.Contains(word) {
+			want++
+		}
+	}
+	if got := p.Words(); want != got {
+		t.Errorf("want %d words, got %d", want, got)
+	}
+
+	if _, err := New(&Args{WordLength: 3}); !errors.Is(err, wordlist.NoBundledWordsErr) {
+		t.Errorf("want %v, got %v", wordlist.NoBundledWordsErr, err)
+	}
+}
+
 func TestValidate(t *testing.T) {
 	list := []string{"foo", "bar", "bam", "zap"}
 	p := Wordle{
@@ -112,6 +171,18 @@ func TestGuess(t *testing.T) {
 		word:           "zap",
 		response:       string([]byte{wordler.ELSEWHERE, wordler.NIL, wordler.ELSEWHERE}),
 		wordsRemaining: 1,
+	}, {
+		list:           []string{"niños", "señal", "leñas", "peras"},
+		guess:          "señal",
+		word:           "leñas",
+		response:       string([]byte{wordler.ELSEWHERE, wordler.CORRECT, wordler.CORRECT, wordler.CORRECT, wordler.ELSEWHERE}),
+		wordsRemaining: 1,
+	}, {
+		list:           []string{"слово", "слива", "плита"},
+		guess:          "слива",
+		word:           "слово",
+		response:       string([]byte{wordler.CORRECT, wordler.CORRECT, wordler.NIL, wordler.CORRECT, wordler.NIL}),
+		wordsRemaining: 1,
 	}, {
 		list:  []string{"a"},
 		guess: "b",
@@ -239,6 +310,36 @@ func TestRemaining(t *testing.T) {
 	}
 }
 
+func TestHistory(t *testing.T) {
+	list := []string{"foo", "bar", "bam", "zap"}
+	p := Wordle{
+		dict:             wordlist.New(list),
+		remaining:        wordlist.New(list),
+		word:             "bar",
+		remainingGuesses: wordler.DEFAULT_GUESSES,
+	}
+	if got := p.History(); len(got) != 0 {
+		t.Errorf("want empty history, got %v", got)
+	}
+
+	for _, g := range []string{"zap", "bogus", "bam", "bar"} {
+		p.Guess(g)
+	}
+	want := []wordler.Row{
+		{Guess: "zap", Response: string([]byte{wordler.NIL, wordler.CORRECT, wordler.NIL})},
+		{Guess: "bam", Response: string([]byte{wordler.CORRECT, wordler.CORRECT, wordler.NIL})},
+		{Guess: "bar", Response: string([]byte{wordler.CORRECT, wordler.CORRECT, wordler.CORRECT})},
+	}
+	if got := p.History(); !reflect.DeepEqual(want, got) {
+		t.Errorf("want %v, got %v", want, got)
+	}
+
+	var nilWordle *Wordle
+	if got := nilWordle.History(); got != nil {
+		t.Errorf("want nil, got %v", got)
+	}
+}
+
 func TestGiveUp(t *testing.T) {
 	list := []string{"a", "b", "c", "d", "e", "f", "g", "h"}
 	p := Wordle{
@@ -438,3 +539,26 @@ func TestSimpleDoubles(t *testing.T) {
 		})
 	}
 }
+
+func TestGrayDuplicates(t *testing.T) {
+	// "eerie" for "crane" scores one 'e' and marks the others gray, so the
+	// solution has exactly one 'e': "there" is out.
+	list := []string{"crane", "there", "brake", "eerie"}
+	p := Wordle{
+		dict:             wordlist.New(list),
+		remaining:        wordlist.New(list),
+		word:             "crane",
+		remainingGuesses: wordler.DEFAULT_GUESSES,
+	}
+
+	response, err := p.Guess("eerie")
+	if err != nil {
+		t.Errorf("want nil, got %v", err)
+	}
+	if want, got := string([]byte{wordler.NIL, wordler.NIL, wordler.ELSEWHERE, wordler.NIL, wordler.CORRECT}), response; want != got {
+		t.Errorf("want %v, got %v", want, got)
+	}
+	if want := wordlist.New([]string{"brake", "crane"}); !want.Equals(p.remaining) {
+		t.Errorf("want %#v, got %#v", want, p.remaining)
+	}
+}
@@ -0,0 +1,94 @@
+package wordler
+
+import (
+	"fmt"
+	"strings"
+	"unicode/utf8"
+)
+
+// notation maps the marks of one response notation to CORRECT, ELSEWHERE and
+// NIL.
+type notation struct {
+	name  string
+	marks map[rune]rune
+}
+
+// notations are the response notations accepted by ParseResponse. A response
+// must be written entirely in one of them.
+var notations = []notation{{
+	name:  fmt.Sprintf("'%c%c%c'", CORRECT, ELSEWHERE, NIL),
+	marks: map[rune]rune{CORRECT: CORRECT, ELSEWHERE: ELSEWHERE, NIL: NIL},
+}, {
+	name: "letters (g/y/b or g/y/x)",
+	marks: map[rune]rune{
+		'g': CORRECT, 'y': ELSEWHERE, 'b': NIL, 'x': NIL,
+		'G': CORRECT, 'Y': ELSEWHERE, 'B': NIL, 'X': NIL,
+	},
+}, {
+	name: "emoji squares",
+	marks: map[rune]rune{
+		'🟩': CORRECT, '🟨': ELSEWHERE, '⬛': NIL, '⬜': NIL,
+		'🟧': CORRECT, '🟦': ELSEWHERE, // high contrast mode
+	},
+}, {
+	name:  "digits (2/1/0)",
+	marks: map[rune]rune{'2': CORRECT, '1': ELSEWHERE, '0': NIL},
+}}
+
+// ParseResponse normalizes a response written in any accepted notation to a
+// string of CORRECT, ELSEWHERE and NIL. Accepted notations are the internal
+// marks, colored letters ("gyb" or "gyx"), emoji squares and digits ("210").
+// The response must be length letters long and must not mix notations.
+func ParseResponse(response string, length int) (string, error) {
+	response = strings.TrimSpace(response)
+	if n := utf8.RuneCountInString(response); n != length {
+		return "", fmt.Errorf("invalid response %q: want %d marks, got %d", response, length, n)
+	}
+	if length == 0 {
+		return "", nil
+	}
+
+	first, _ := utf8.DecodeRuneInString(response)
+	for _, n := range notations {
+		if _, ok := n.marks[first]; !ok {
+			continue
+		}
+		var b strings.Builder
+		for i, r := range []rune(response) {
+			mark, ok := n.marks[r]
+			if !ok {
+				return "", fmt.Errorf("invalid response %q: mark %d (%q) is not in the %s notation used by mark 1", response, i+1, r, n.name)
+			}
+			b.WriteRune(mark)
+		}
+		return b.String(), nil
+	}
+
+	var names []string
+	for _, n := range notations {
+		names = append(names, n.name)
+	}
+	return "", fmt.Errorf("invalid response %q: %q is not a recognized mark; use one of %s", response, first, strings.Join(names, ", "))
+}
+
+// ParseRow parses a guess and its response, written either as
+// "guess:response" or as "guess response". The response may use any notation
+// accepted by ParseResponse.
+func ParseRow(s string) (Row, error) {
+	guess, response, ok := strings.Cut(strings.TrimSpace(s), ":")
+	if !ok {
+		fields := strings.Fields(s)
+		if len(fields) != 2 {
+			return Row{}, fmt.Errorf("invalid row %q: want \"guess:response\" or \"guess response\"", s)
+		}
+		guess, response = fields[0], fields[1]
+	}
+	if guess = strings.TrimSpace(guess); guess == "" {
+		return Row{}, fmt.Errorf("invalid row %q: missing guess", s)
+	}
+	response, err := ParseResponse(response, utf8.RuneCountInString(guess))
+	if err != nil {
+		return Row{}, fmt.Errorf("invalid row %q: %w", s, err)
+	}
+	return Row{Guess: guess, Response: response}, nil
+}
@@ -0,0 +1,73 @@
+package wordler
+
+import "testing"
+
+func TestParseResponse(t *testing.T) {
+	want := string([]byte{CORRECT, ELSEWHERE, NIL, NIL, CORRECT})
+	for _, response := range []string{
+		want,
+		"gybbg",
+		"gyxxg",
+		"GYBXG",
+		"🟩🟨⬛⬜🟩",
+		"🟧🟦⬛⬛🟧",
+		"21002",
+		" gybbg ",
+	} {
+		t.Run(response, func(t *testing.T) {
+			got, err := ParseResponse(response, len(want))
+			if err != nil {
+				t.Fatalf("got error %v", err)
+			}
+			if got != want {
+				t.Errorf("want %v, got %v", want, got)
+			}
+		})
+	}
+
+	for _, response := range []string{
+		"",
+		"gyb",        // too short
+		"gybbgg",     // too long
+		"🟩🟨⬛⬜",       // too short
+		"gy_bg",      // mixed notation
+		"21b02",      // mixed notation
+		"🟩y⬛⬜🟩",      // mixed notation
+		"gy bg",      // space
+		"abcde",      // not a notation
+		"++*_+extra", // too long
+	} {
+		t.Run(response, func(t *testing.T) {
+			if got, err := ParseResponse(response, len(want)); err == nil {
+				t.Errorf("want error, got %v", got)
+			}
+		})
+	}
+
+	if got, err := ParseResponse("", 0); err != nil || got != "" {
+		t.Errorf("want empty response, got %q, %v", got, err)
+	}
+}
+
+func TestParseRow(t *testing.T) {
+	want := Row{Guess: "crane", Response: string([]byte{NIL, NIL, CORRECT, ELSEWHERE, NIL})}
+	for _, row := range []string{"crane:__+*_", "crane __+*_", " crane:bbgyb ", "crane\t⬛⬛🟩🟨⬛"} {
+		t.Run(row, func(t *testing.T) {
+			got, err := ParseRow(row)
+			if err != nil {
+				t.Fatalf("got error %v", err)
+			}
+			if got != want {
+				t.Errorf("want %v, got %v", want, got)
+			}
+		})
+	}
+
+	for _, row := range []string{"", "crane", "crane:", "crane:__+*", "crane __+ *_", "crane:__+*_:", ":"} {
+		t.Run(row, func(t *testing.T) {
+			if got, err := ParseRow(row); err == nil {
+				t.Errorf("want error, got %v", got)
+			}
+		})
+	}
+}
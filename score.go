@@ -0,0 +1,51 @@
+package wordler
+
+// Row is a guess and the response it was given.
+type Row struct {
+	Guess, Response string
+}
+
+// Score returns the response to guess when the puzzle's answer is solution:
+// a string of CORRECT, ELSEWHERE and NIL, one per letter of guess. Each letter
+// of solution is matched at most once, with CORRECT matches taking precedence.
+// Guess and solution must be the same length.
+func Score(guess, solution string) string {
+	g, s := []rune(guess), []rune(solution)
+	response := make([]rune, len(g))
+	unmatched := make(map[rune]int, len(s)) // letters of solution not yet matched
+
+	for i, c := range g {
+		if i < len(s) && s[i] == c {
+			response[i] = CORRECT
+		} else if i < len(s) {
+			unmatched[s[i]]++
+		}
+	}
+	for i, c := range g {
+		switch {
+		case response[i] == CORRECT:
+		case unmatched[c] > 0:
+			response[i] = ELSEWHERE
+			unmatched[c]--
+		default:
+			response[i] = NIL
+		}
+	}
+	return string(response)
+}
+
+// Mismatches returns the number of positions at which responses a and b have
+// different marks, as when a response lies about some of its letters.
+func Mismatches(a, b string) int {
+	ra, rb := []rune(a), []rune(b)
+	if len(ra) < len(rb) {
+		ra, rb = rb, ra
+	}
+	n := len(ra) - len(rb)
+	for i, c := range rb {
+		if ra[i] != c {
+			n++
+		}
+	}
+	return n
+}
@@ -0,0 +1,79 @@
+package wordler
+
+import "testing"
+
+func TestScore(t *testing.T) {
+	cases := []struct {
+		word, guess string
+		response    []byte
+	}{{
+		word:     "forty",
+		guess:    "worry",
+		response: []byte{NIL, CORRECT, CORRECT, NIL, CORRECT},
+	}, {
+		word:     "forty",
+		guess:    "robot",
+		response: []byte{ELSEWHERE, CORRECT, NIL, NIL, ELSEWHERE},
+	}, {
+		word:     "foyer",
+		guess:    "carer",
+		response: []byte{NIL, NIL, NIL, CORRECT, CORRECT},
+	}, {
+		word:     "ab",
+		guess:    "aa",
+		response: []byte{CORRECT, NIL},
+	}, {
+		word:     "aab",
+		guess:    "baa",
+		response: []byte{ELSEWHERE, CORRECT, ELSEWHERE},
+	}, {
+		word:     "aab",
+		guess:    "bab",
+		response: []byte{NIL, CORRECT, CORRECT},
+	}, {
+		word:     "aab",
+		guess:    "bba",
+		response: []byte{ELSEWHERE, NIL, ELSEWHERE},
+	}, {
+		word:     "machin",
+		guess:    "dreamt",
+		response: []byte{NIL, NIL, NIL, ELSEWHERE, ELSEWHERE, NIL},
+	}, {
+		word:     "machin",
+		guess:    "whimmy",
+		response: []byte{NIL, ELSEWHERE, ELSEWHERE, ELSEWHERE, NIL, NIL},
+	}, {
+		word:     "crane",
+		guess:    "crane",
+		response: []byte{CORRECT, CORRECT, CORRECT, CORRECT, CORRECT},
+	}}
+
+	for _, c := range cases {
+		t.Run(c.guess+"_"+c.word, func(t *testing.T) {
+			if want, got := string(c.response), Score(c.guess, c.word); want != got {
+				t.Errorf("want %v, got %v", want, got)
+			}
+		})
+	}
+}
+
+func TestMismatches(t *testing.T) {
+	cases := []struct {
+		a, b string
+		want int
+	}{
+		{"+*_+_", "+*_+_", 0},
+		{"+*_+_", "+__+_", 1},
+		{"+++++", "_____", 5},
+		{"+*_", "+*_+_", 2},
+		{"", "", 0},
+	}
+	for _, c := range cases {
+		if got := Mismatches(c.a, c.b); got != c.want {
+			t.Errorf("%q, %q: want %d, got %d", c.a, c.b, c.want, got)
+		}
+		if got := Mismatches(c.b, c.a); got != c.want {
+			t.Errorf("%q, %q: want %d, got %d", c.b, c.a, c.want, got)
+		}
+	}
+}
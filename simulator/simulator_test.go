@@ -2,8 +2,10 @@ package simulator
 
 import (
 	"fmt"
+	"strings"
 	"testing"
 
+	"wordler"
 	"wordler/puzzler"
 	"wordler/solver"
 	"wordler/wordlist"
@@ -108,3 +110,71 @@ func TestSimulations(t *testing.T) {
 		})
 	}
 }
+
+// TestSimulationLoaders plays games with the dictionary passed to the Puzzler
+// as Args.Loader and to the Solver by solver.Load, leaving wordlist.Loader
+// alone.
+func TestSimulationLoaders(t *testing.T) {
+	f := &fakeLoader{length: 3}
+	if _, err := f.Load(); err != nil {
+		t.Fatal(err)
+	}
+	for _, solution := range f.words {
+		t.Run(solution, func(t *testing.T) {
+			p, err := puzzler.New(&puzzler.Args{Hard: true, WordLength: 3, Guesses: wordler.DEFAULT_GUESSES, Solution: solution, Loader: f})
+			if err != nil {
+				t.Fatalf("Failed to make a Puzzler: %v", err)
+			}
+			s, err := solver.Load(f)
+			if err != nil {
+				t.Fatalf("Failed to make a Solver: %v", err)
+			}
+			for p.Guesses() > 0 {
+				if p.Words() != s.Remaining() {
+					t.Fatalf("%d Puzzler words != %d Solver words", p.Words(), s.Remaining())
+				}
+				guess := s.Guess()
+				response, err := p.Guess(guess)
+				if err != nil {
+					t.Fatalf("ERROR: %v", err)
+				}
+				if guess == solution {
+					return
+				}
+				if err := s.React(guess, response); err != nil {
+					t.Fatalf("ERROR: %v", err)
+				}
+			}
+			t.Errorf("'%s' not solved", solution)
+		})
+	}
+}
+
+// BenchmarkGame measures the cost of a whole game: making a Puzzler and a
+// Solver with the Wordle dictionary and playing until the game is over.
+func BenchmarkGame(b *testing.B) {
+	args := &puzzler.Args{Hard: true, WordLength: wordler.DEFAULT_WORD_LENGTH, Guesses: wordler.DEFAULT_GUESSES}
+	for i := 0; i < b.N; i++ {
+		// Step through the dictionary so that every run plays the same games.
+		args.Solution = wordler.Dictionary[(i*7919)%len(wordler.Dictionary)]
+		p, err := puzzler.New(args)
+		if err != nil {
+			b.Fatalf("Failed to make a Puzzler: %v", err)
+		}
+		s := solver.From(wordler.Dictionary)
+
+		for p.Guesses() > 0 {
+			guess := s.Guess()
+			response, err := p.Guess(guess)
+			if err != nil {
+				b.Fatalf("ERROR: %v", err)
+			}
+			if response == strings.Repeat(string(wordler.CORRECT), len(guess)) {
+				break
+			}
+			if err := s.React(guess, response); err != nil {
+				b.Fatalf("ERROR: %v", err)
+			}
+		}
+	}
+}
@@ -0,0 +1,136 @@
+package solver
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"wordler"
+)
+
+// Book is an opening book: the guess to make next after a history of guesses
+// and responses. Keys are histories as BookKey makes them; the opening guess
+// has the empty history.
+type Book map[string]string
+
+// BookKey returns the Book key of a history of rows: "guess:response" for each
+// row, separated by spaces.
+func BookKey(rows []wordler.Row) string {
+	fields := make([]string, len(rows))
+	for i, row := range rows {
+		fields[i] = row.Guess + ":" + row.Response
+	}
+	return strings.Join(fields, " ")
+}
+
+// ReadBook reads a Book, one entry per line: the rows of a history, as
+// wordler.ParseRow accepts them without spaces, followed by the guess to make
+// after them, e.g. "crane:__+*_ sloth". A line with only a guess gives the
+// opening guess. Blank lines and lines starting with '#' are skipped.
+func ReadBook(r io.Reader) (Book, error) {
+	b := make(Book)
+	scanner := bufio.NewScanner(r)
+	for n := 1; scanner.Scan(); n++ {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) == 0 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+		var rows []wordler.Row
+		for _, field := range fields[:len(fields)-1] {
+			row, err := wordler.ParseRow(field)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", n, err)
+			}
+			rows = append(rows, row)
+		}
+		guess := fields[len(fields)-1]
+		if strings.Contains(guess, ":") {
+			return nil, fmt.Errorf("line %d: %q is a row, not a guess", n, guess)
+		}
+		b[BookKey(rows)] = guess
+	}
+	return b, scanner.Err()
+}
+
+// LoadBook reads the Book in the named file, as ReadBook does.
+func LoadBook(path string) (Book, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	b, err := ReadBook(file)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return b, nil
+}
+
+// Write writes b as ReadBook reads it: shorter histories first, and otherwise
+// in alphabetical order.
+func (b Book) Write(w io.Writer) error {
+	keys := make([]string, 0, len(b))
+	for key := range b {
+		keys = append(keys, key)
+	}
+	sort.Slice(keys, func(i, j int) bool {
+		ni, nj := len(strings.Fields(keys[i])), len(strings.Fields(keys[j]))
+		if ni != nj {
+			return ni < nj
+		}
+		return keys[i] < keys[j]
+	})
+	for _, key := range keys {
+		line := b[key]
+		if key != "" {
+			line = key + " " + line
+		}
+		if _, err := fmt.Fprintln(w, line); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Book returns an opening book of t's first depth guesses: its opening guess,
+// then its guess after each response to that, and so on.
+func (t *Tree) Book(depth int) Book {
+	b := make(Book)
+	var walk func(t *Tree, rows []wordler.Row, depth int)
+	walk = func(t *Tree, rows []wordler.Row, depth int) {
+		if t == nil || depth == 0 {
+			return
+		}
+		b[BookKey(rows)] = t.Guess
+		for response, next := range t.Next {
+			walk(next, append(rows[:len(rows):len(rows)], wordler.Row{Guess: t.Guess, Response: response}), depth-1)
+		}
+	}
+	walk(t, nil, depth)
+	return b
+}
+
+// UseBook makes the Solver look each guess up in b before computing it, or
+// following its decision tree. The book isn't used once one possible solution
+// or none remains, and guesses the Solver may not make under hard rules, or
+// that were reported as not in wordle or refused, are skipped.
+func (s *Solver) UseBook(b Book) {
+	if s != nil {
+		s.book = b
+	}
+}
+
+// fromBook returns the book's guess for the rows so far, if it has one.
+func (s *Solver) fromBook() (string, bool) {
+	if s.Remaining() <= 1 {
+		return "", false
+	}
+	guess, ok := s.book[BookKey(s.rows)]
+	if !ok || s.refused[guess] || !s.g.Contains(guess) {
+		return "", false
+	}
+	return guess, true
+}
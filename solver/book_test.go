@@ -0,0 +1,148 @@
+package solver
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestReadBook(t *testing.T) {
+	in := `# opener
+zoo
+zoo:___ bay
+zoo:bxx bay:ggx bam
+zoo:+__ foo
+`
+	b, err := ReadBook(strings.NewReader(in))
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := Book{
+		"":                "zoo",
+		"zoo:___":         "bay",
+		"zoo:___ bay:++_": "bam",
+		"zoo:+__":         "foo",
+	}
+	if !reflect.DeepEqual(want, b) {
+		t.Errorf("want %v, got %v", want, b)
+	}
+
+	var out bytes.Buffer
+	if err := b.Write(&out); err != nil {
+		t.Fatal(err)
+	}
+	if want, got := "zoo\nzoo:+__ foo\nzoo:___ bay\nzoo:___ bay:++_ bam\n", out.String(); want != got {
+		t.Errorf("want %q, got %q", want, got)
+	}
+
+	for _, in := range []string{"zoo:+ bay\n", "zoo:___\n"} {
+		if _, err := ReadBook(strings.NewReader(in)); err == nil {
+			t.Errorf("%q: want error", in)
+		}
+	}
+}
+
+func TestUseBook(t *testing.T) {
+	s := From(treeWords)
+	s.UseBook(Book{"": "zoo", "zoo:___": "bay", "zoo:___ bay:++_": "bam"})
+	if want, got := "zoo", s.Guess(); want != got {
+		t.Fatalf("want %q, got %q", want, got)
+	}
+	if err := s.React("zoo", "___"); err != nil {
+		t.Fatal(err)
+	}
+	if want, got := "bay", s.Guess(); want != got {
+		t.Fatalf("want %q, got %q", want, got)
+	}
+	if err := s.React("bay", "++_"); err != nil {
+		t.Fatal(err)
+	}
+
+	// Book guesses not in wordle are skipped.
+	s.NotInWordle("bam")
+	if want, got := "bar", s.Guess(); want != got {
+		t.Errorf("want %q, got %q", want, got)
+	}
+
+	// Book guesses not in the dictionary, or of the wrong length, are skipped.
+	for _, guess := range []string{"qzx", "bays"} {
+		s = From(treeWords)
+		s.UseBook(Book{"": guess})
+		if want, got := From(treeWords).Guess(), s.Guess(); want != got {
+			t.Errorf("%q: want %q, got %q", guess, want, got)
+		}
+	}
+
+	// Once one word remains, it's guessed rather than the book's guess.
+	s = From(treeWords)
+	s.UseBook(Book{"": "zoo", "zoo:___": "bay", "zoo:___ bay:++_": "bam", "zoo:___ bay:++_ bam:++_": "bay"})
+	for _, row := range [][2]string{{"zoo", "___"}, {"bay", "++_"}, {"bam", "++_"}} {
+		if err := s.React(row[0], row[1]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if want, got := "bar", s.Guess(); want != got {
+		t.Errorf("want %q, got %q", want, got)
+	}
+
+	// Book guesses that don't follow hard rules, or were refused, are
+	// skipped; refused words stay possible solutions.
+	s, computed := From(treeWords), From(treeWords)
+	s.UseBook(Book{"zoo:___ bay:++_": "zap"})
+	for _, row := range [][2]string{{"zoo", "___"}, {"bay", "++_"}} {
+		if err := s.React(row[0], row[1]); err != nil {
+			t.Fatal(err)
+		}
+		if err := computed.React(row[0], row[1]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if want, got := computed.Guess(), s.Guess(); want != got {
+		t.Errorf("want %q, got %q", want, got)
+	}
+	s = From(treeWords)
+	s.UseBook(Book{"": "zoo"})
+	s.Refused("zoo")
+	if want, got := From(treeWords).Guess(), s.Guess(); want != got {
+		t.Errorf("want %q, got %q", want, got)
+	}
+	if want, got := len(treeWords), s.Remaining(); want != got {
+		t.Errorf("want %d words, got %d", want, got)
+	}
+
+	// Histories the book doesn't have are computed.
+	s = From(treeWords)
+	s.UseBook(Book{"zoo:___": "bay"})
+	if want, got := From(treeWords).Guess(), s.Guess(); want != got {
+		t.Errorf("want %q, got %q", want, got)
+	}
+}
+
+func TestTreeBook(t *testing.T) {
+	tree := &Tree{Guess: "zoo", Words: 6, Next: map[string]*Tree{
+		"___": {Guess: "bay", Words: 3, Next: map[string]*Tree{
+			"++_": {Guess: "bam", Words: 2},
+		}},
+		"+__": {Guess: "zap", Words: 1},
+	}}
+	want := Book{"": "zoo", "zoo:___": "bay", "zoo:+__": "zap"}
+	if got := tree.Book(2); !reflect.DeepEqual(want, got) {
+		t.Errorf("want %v, got %v", want, got)
+	}
+
+	// A solver using the book guesses as one following the tree.
+	s := From(treeWords)
+	s.UseBook(tree.Book(3))
+	for _, row := range [][2]string{{"zoo", "___"}, {"bay", "++_"}} {
+		if want, got := row[0], s.Guess(); want != got {
+			t.Fatalf("want %q, got %q", want, got)
+		}
+		if err := s.React(row[0], row[1]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if want, got := "bam", s.Guess(); want != got {
+		t.Errorf("want %q, got %q", want, got)
+	}
+}
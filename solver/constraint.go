@@ -0,0 +1,287 @@
+package solver
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"wordler"
+	"wordler/wordlist"
+)
+
+// Constraint is what guesses and their responses reveal about the solution:
+// the letter known to be at each position, the letters known not to be at each
+// position, and the minimum and maximum count of each letter.
+type Constraint struct {
+	length   int
+	fixed    []rune          // fixed[i] is the letter at position i; 0 if unknown
+	excluded []map[rune]bool // excluded[i] holds letters not at position i
+	min      map[rune]int    // the solution has at least min[c] of letter c
+	max      map[rune]int    // the solution has at most max[c] of letter c, if present
+}
+
+// NewConstraint returns the Constraint revealed by guess and its response,
+// which must be made up of wordler.CORRECT, wordler.ELSEWHERE and wordler.NIL.
+func NewConstraint(guess, response string) (*Constraint, error) {
+	g, r := []rune(guess), []rune(response)
+	if len(g) != len(r) {
+		return nil, fmt.Errorf("invalid response %q for guess %q: lengths differ", response, guess)
+	}
+
+	c := newConstraint(len(g))
+	gray := make(map[rune]bool)
+	for i, l := range g {
+		switch r[i] {
+		case wordler.CORRECT:
+			c.fixed[i] = l
+			c.min[l]++
+		case wordler.ELSEWHERE:
+			c.excluded[i][l] = true
+			c.min[l]++
+		case wordler.NIL:
+			c.excluded[i][l] = true
+			gray[l] = true
+		default:
+			return nil, fmt.Errorf("invalid response %q: unknown mark %q", response, r[i])
+		}
+	}
+	// A gray letter means the solution has no more of it than were marked
+	// CORRECT or ELSEWHERE: guessing "eerie" for "crane" scores one 'e' and
+	// marks the other two gray, so "crane" has exactly one 'e'.
+	for l := range gray {
+		c.max[l] = c.min[l]
+	}
+	return c, nil
+}
+
+// newConstraint returns an empty Constraint for words of the given length.
+func newConstraint(length int) *Constraint {
+	c := &Constraint{
+		length:   length,
+		fixed:    make([]rune, length),
+		excluded: make([]map[rune]bool, length),
+		min:      make(map[rune]int),
+		max:      make(map[rune]int),
+	}
+	for i := range c.excluded {
+		c.excluded[i] = make(map[rune]bool)
+	}
+	return c
+}
+
+// Length returns the word length the Constraint applies to.
+func (c *Constraint) Length() int {
+	if c == nil {
+		return 0
+	}
+	return c.length
+}
+
+// Merge adds everything known by o to c. An empty Constraint takes on the
+// length of o; otherwise both must be for words of the same length.
+func (c *Constraint) Merge(o *Constraint) error {
+	if o == nil || o.length == 0 {
+		return nil
+	}
+	if c.length == 0 {
+		*c = *newConstraint(o.length)
+	}
+	if c.length != o.length {
+		return fmt.Errorf("cannot merge constraints for %d- and %d-letter words", c.length, o.length)
+	}
+
+	for i, l := range o.fixed {
+		switch {
+		case l == 0 || c.fixed[i] == l:
+		case c.fixed[i] == 0:
+			c.fixed[i] = l
+		default:
+			// Two different letters at one position: nothing can match.
+			c.excluded[i][c.fixed[i]] = true
+		}
+		for x := range o.excluded[i] {
+			c.excluded[i][x] = true
+		}
+	}
+	for l, n := range o.min {
+		if n > c.min[l] {
+			c.min[l] = n
+		}
+	}
+	for l, n := range o.max {
+		if m, ok := c.max[l]; !ok || n < m {
+			c.max[l] = n
+		}
+	}
+	return nil
+}
+
+// Clone returns a copy of c.
+func (c *Constraint) Clone() *Constraint {
+	clone := newConstraint(c.Length())
+	clone.Merge(c)
+	return clone
+}
+
+// Hard returns the Constraint that "hard" rules place on guesses: letters
+// known to be in the right place must stay there, and letters known to be in
+// the puzzle must be included, but may stay in a place known to be incorrect.
+func (c *Constraint) Hard() *Constraint {
+	hard := newConstraint(c.Length())
+	if c == nil {
+		return hard
+	}
+	copy(hard.fixed, c.fixed)
+	for l, n := range c.min {
+		if n > 0 {
+			hard.min[l] = 1
+		}
+	}
+	return hard
+}
+
+// Matches returns true if word satisfies the Constraint.
+func (c *Constraint) Matches(word string) bool {
+	w := []rune(word)
+	if c == nil || c.length == 0 {
+		return true
+	}
+	if len(w) != c.length {
+		return false
+	}
+
+	counts := make(map[rune]int, len(w))
+	for i, l := range w {
+		if f := c.fixed[i]; f != 0 && f != l {
+			return false
+		}
+		if c.excluded[i][l] {
+			return false
+		}
+		counts[l]++
+	}
+	for l, n := range c.min {
+		if counts[l] < n {
+			return false
+		}
+	}
+	for l, n := range c.max {
+		if counts[l] > n {
+			return false
+		}
+	}
+	return true
+}
+
+// Regexp returns an expression matching words that satisfy the Constraint's
+// per-position letters; it ignores letter counts.
+func (c *Constraint) Regexp() *regexp.Regexp {
+	if c == nil || c.length == 0 {
+		return regexp.MustCompile("")
+	}
+	var b strings.Builder
+	b.WriteString("^")
+	for i, f := range c.fixed {
+		switch {
+		case f != 0 && c.excluded[i][f]:
+			b.WriteString(`[^\s\S]`) // matches nothing
+		case f != 0:
+			b.WriteString(regexp.QuoteMeta(string(f)))
+		case len(c.excluded[i]) > 0:
+			b.WriteString("[^" + wordlist.QuoteClass(string(wordlist.SortedLetters(c.excluded[i]))) + "]")
+		default:
+			b.WriteString(".")
+		}
+	}
+	b.WriteString("$")
+	return regexp.MustCompile(b.String())
+}
+
+// Options returns the wordlist Options that keep only words satisfying the
+// Constraint, for use with wordlist.New or the WordList KeepOnly and Delete
+// methods.
+func (c *Constraint) Options() []wordlist.Option {
+	if c == nil || c.length == 0 {
+		return nil
+	}
+	options := []wordlist.Option{wordlist.KeepOnlyOption{Exp: c.Regexp()}}
+	for _, l := range wordlist.SortedLetters(c.min) {
+		if n := c.min[l]; n > 0 {
+			options = append(options, wordlist.KeepOnlyOption{Exp: atLeast(l, n)})
+		}
+	}
+	for _, l := range wordlist.SortedLetters(c.max) {
+		options = append(options, wordlist.DeleteOption{Exp: atLeast(l, c.max[l]+1)})
+	}
+	return options
+}
+
+// Apply removes words that don't satisfy the Constraint from w.
+func (c *Constraint) Apply(w *wordlist.WordList) {
+	if c == nil || c.length == 0 {
+		return
+	}
+	for i, f := range c.fixed {
+		if f != 0 {
+			w.KeepOnlyAt(i, f)
+		}
+		for l := range c.excluded[i] {
+			w.DeleteAt(i, l)
+		}
+	}
+	for l, n := range c.min {
+		w.KeepOnlyContaining(l, n)
+	}
+	for l, n := range c.max {
+		w.DeleteContaining(l, n+1)
+	}
+}
+
+// String fulfills the fmt.Stringer interface. For example, after guess "trace"
+// for solution "crate":
+//
+//	pattern ".ra.e"; not t@1, c@4; at least 1 a, 1 c, 1 e, 1 r, 1 t
+func (c *Constraint) String() string {
+	if c == nil || c.length == 0 {
+		return "nothing known"
+	}
+
+	var pattern strings.Builder
+	var excluded []string
+	for i, f := range c.fixed {
+		if f == 0 {
+			pattern.WriteRune('.')
+		} else {
+			pattern.WriteRune(f)
+		}
+		for _, l := range wordlist.SortedLetters(c.excluded[i]) {
+			excluded = append(excluded, fmt.Sprintf("%c@%d", l, i+1))
+		}
+	}
+	parts := []string{fmt.Sprintf("pattern %q", pattern.String())}
+	if len(excluded) > 0 {
+		parts = append(parts, "not "+strings.Join(excluded, ", "))
+	}
+
+	var min, max []string
+	for _, l := range wordlist.SortedLetters(c.min) {
+		if n := c.min[l]; n > 0 {
+			min = append(min, fmt.Sprintf("%d %c", n, l))
+		}
+	}
+	for _, l := range wordlist.SortedLetters(c.max) {
+		max = append(max, fmt.Sprintf("%d %c", c.max[l], l))
+	}
+	if len(min) > 0 {
+		parts = append(parts, "at least "+strings.Join(min, ", "))
+	}
+	if len(max) > 0 {
+		parts = append(parts, "at most "+strings.Join(max, ", "))
+	}
+	return strings.Join(parts, "; ")
+}
+
+// atLeast returns an expression matching words with at least n of letter l.
+func atLeast(l rune, n int) *regexp.Regexp {
+	return regexp.MustCompile(fmt.Sprintf("(?:%s.*){%d}", regexp.QuoteMeta(string(l)), n))
+}
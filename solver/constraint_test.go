@@ -0,0 +1,153 @@
+package solver
+
+import (
+	"testing"
+
+	"wordler"
+	"wordler/wordlist"
+)
+
+func TestNewConstraint(t *testing.T) {
+	c, err := NewConstraint("trace", wordler.Score("trace", "crate"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, got := `pattern ".ra.e"; not t@1, c@4; at least 1 a, 1 c, 1 e, 1 r, 1 t`, c.String(); want != got {
+		t.Errorf("want %v, got %v", want, got)
+	}
+	if want, got := 5, c.Length(); want != got {
+		t.Errorf("want %d, got %d", want, got)
+	}
+
+	c, err = NewConstraint("sloth", wordler.Score("sloth", "crate"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, got := `pattern "...t."; not s@1, l@2, o@3, h@5; at least 1 t; at most 0 h, 0 l, 0 o, 0 s`, c.String(); want != got {
+		t.Errorf("want %v, got %v", want, got)
+	}
+
+	for _, c := range []struct{ guess, response string }{
+		{"trace", "++"},
+		{"trace", "+++++++"},
+		{"trace", "gyggg"},
+	} {
+		if _, err := NewConstraint(c.guess, c.response); err == nil {
+			t.Errorf("%v, %v: want error, got nil", c.guess, c.response)
+		}
+	}
+}
+
+func TestConstraintMerge(t *testing.T) {
+	c := &Constraint{}
+	if want, got := "nothing known", c.String(); want != got {
+		t.Errorf("want %v, got %v", want, got)
+	}
+	for _, guess := range []string{"sloth", "trace"} {
+		row, err := NewConstraint(guess, wordler.Score(guess, "crate"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := c.Merge(row); err != nil {
+			t.Fatal(err)
+		}
+	}
+	want := `pattern ".rate"; not s@1, t@1, l@2, o@3, c@4, h@5; at least 1 a, 1 c, 1 e, 1 r, 1 t; at most 0 h, 0 l, 0 o, 0 s`
+	if got := c.String(); want != got {
+		t.Errorf("want %v, got %v", want, got)
+	}
+
+	// Merging is idempotent.
+	if err := c.Merge(c.Clone()); err != nil {
+		t.Fatal(err)
+	}
+	if got := c.String(); want != got {
+		t.Errorf("want %v, got %v", want, got)
+	}
+
+	short, _ := NewConstraint("abc", "___")
+	if err := c.Merge(short); err == nil {
+		t.Error("want error merging different lengths, got nil")
+	}
+
+	// Conflicting letters at one position match nothing.
+	a, _ := NewConstraint("ab", "+_")
+	b, _ := NewConstraint("ba", "+_")
+	if err := a.Merge(b); err != nil {
+		t.Fatal(err)
+	}
+	for _, word := range []string{"aa", "ab", "ba", "bb"} {
+		if a.Matches(word) {
+			t.Errorf("%v matches %v", a, word)
+		}
+		if a.Regexp().MatchString(word) {
+			t.Errorf("%v matches %v", a.Regexp(), word)
+		}
+	}
+}
+
+func TestConstraintMatches(t *testing.T) {
+	list := []string{"crate", "trace", "react", "cater", "irate", "sloth", "eerie", "abbey", "crane", "tract", "there", "brake", "blurb", "bubba"}
+	for _, solution := range list {
+		for _, guess := range list {
+			c, err := NewConstraint(guess, wordler.Score(guess, solution))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !c.Matches(solution) {
+				t.Errorf("%v (from %v) doesn't match solution %v", c, guess, solution)
+			}
+			// A word matches exactly when it would give the same response.
+			for _, word := range list {
+				if want, got := wordler.Score(guess, word) == wordler.Score(guess, solution), c.Matches(word); want != got {
+					t.Errorf("%v (from %v) matches %v: want %t, got %t", c, guess, word, want, got)
+				}
+			}
+
+			// Options and Matches agree.
+			var matching []string
+			for _, word := range list {
+				if c.Matches(word) {
+					matching = append(matching, word)
+				}
+			}
+			want := wordlist.New(matching)
+			got := wordlist.New(list, c.Options()...)
+			if !want.Equals(got) {
+				t.Errorf("%v: want %#v, got %#v", c, want, got)
+			}
+			got = wordlist.New(list)
+			c.Apply(got)
+			if !want.Equals(got) {
+				t.Errorf("%v: want %#v, got %#v", c, want, got)
+			}
+		}
+	}
+
+	c, _ := NewConstraint("abbey", "_**__")
+	for word, want := range map[string]bool{
+		"bribe": false, // b@1 is fine, but e is gray
+		"blurb": true,
+		"blurt": false, // needs 2 b
+		"bubba": false, // a is gray
+		"zzbbz": false, // b@3 is yellow
+		"ebbbz": false, // e is gray
+		"short": false, // needs b
+		"blob":  false, // too short
+	} {
+		if got := c.Matches(word); want != got {
+			t.Errorf("%v matches %v: want %t, got %t", c, word, want, got)
+		}
+	}
+}
+
+func TestConstraintHard(t *testing.T) {
+	c, _ := NewConstraint("trace", wordler.Score("trace", "crate"))
+	if want, got := `pattern ".ra.e"; at least 1 a, 1 c, 1 e, 1 r, 1 t`, c.Hard().String(); want != got {
+		t.Errorf("want %v, got %v", want, got)
+	}
+	var nilConstraint *Constraint
+	if want, got := "nothing known", nilConstraint.Hard().String(); want != got {
+		t.Errorf("want %v, got %v", want, got)
+	}
+}
@@ -0,0 +1,59 @@
+package solver
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"wordler"
+	"wordler/wordlist"
+)
+
+// Tolerate makes the Solver allow up to n wrong marks in each response, as in
+// Fibble, where every response but a win has one. Rather than trusting each
+// response, the Solver keeps every word whose score differs from it in at most
+// n places, so Known no longer applies. Call Tolerate before React.
+func (s *Solver) Tolerate(n int) {
+	if s != nil && n >= 0 {
+		s.lies = n
+	}
+}
+
+// Lies returns the number of wrong marks the Solver allows in each response.
+func (s *Solver) Lies() int {
+	if s == nil {
+		return 0
+	}
+	return s.lies
+}
+
+// reactToLies reacts to a parsed response that may have up to s.lies wrong
+// marks. A winning response is taken to be true.
+func (s *Solver) reactToLies(guess, response string) error {
+	s.rows = append(s.rows, wordler.Row{Guess: guess, Response: response})
+	if response == strings.Repeat(string(wordler.CORRECT), len([]rune(guess))) {
+		s.s = wordlist.New([]string{guess})
+		s.g = s.s.Clone()
+		return nil
+	}
+
+	s.s.KeepOnlyFunc(func(word string) bool {
+		return word != guess && wordler.Mismatches(wordler.Score(guess, word), response) <= s.lies
+	})
+	s.g.Delete(regexp.MustCompile("^" + regexp.QuoteMeta(guess) + "$"))
+	debug("%d words within %d lies", s.s.Length(), s.lies)
+	return nil
+}
+
+// explainLie returns why word is inconsistent with row, the n-th guess, when
+// up to lies of its marks may be wrong, or the empty string if it isn't.
+func explainLie(row wordler.Row, n int, word string, lies int) string {
+	if word == row.Guess {
+		return fmt.Sprintf("was guess %d, which didn't win", n)
+	}
+	score := wordler.Score(row.Guess, word)
+	if m := wordler.Mismatches(score, row.Response); m > lies {
+		return fmt.Sprintf("would score '%s' for guess %d ('%s'), which differs from '%s' in %d places", score, n, row.Guess, row.Response, m)
+	}
+	return ""
+}
@@ -0,0 +1,77 @@
+package solver
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"wordler"
+	"wordler/puzzler"
+)
+
+func TestTolerate(t *testing.T) {
+	s := From([]string{"foo", "bar", "bam", "zap", "zoo"})
+	s.Tolerate(1)
+	if s.Lies() != 1 {
+		t.Errorf("want 1 lie, got %d", s.Lies())
+	}
+	// If the solution is "bar", the truth is "++_"; "+__" lies about one mark.
+	if err := s.React("bam", "+__"); err != nil {
+		t.Fatal(err)
+	}
+	// foo: ___ (1 wrong), bar: ++_ (1), zap: _+_ (2), zoo: ___ (1).
+	if want, got := []string{"bar", "foo", "zoo"}, s.Candidates(); !reflect.DeepEqual(want, got) {
+		t.Errorf("want %v, got %v", want, got)
+	}
+
+	for word, want := range map[string]string{
+		"zap": "would score '_+_' for guess 1 ('bam'), which differs from '+__' in 2 places",
+		"bam": "was guess 1, which didn't win",
+	} {
+		e, err := s.Why(word)
+		if err != nil {
+			t.Fatalf("%s: %v", word, err)
+		}
+		if e.Reason != want {
+			t.Errorf("%s: want %q, got %q", word, want, e.Reason)
+		}
+	}
+	if _, err := s.Why("foo"); !errors.Is(err, NotEliminatedErr) {
+		t.Errorf("want %v, got %v", NotEliminatedErr, err)
+	}
+
+	if err := s.React("bar", "+++"); err != nil {
+		t.Fatal(err)
+	}
+	if want, got := []string{"bar"}, s.Candidates(); !reflect.DeepEqual(want, got) {
+		t.Errorf("want %v, got %v", want, got)
+	}
+}
+
+func TestFibbleGame(t *testing.T) {
+	// With a lie in every response, the solution is never ruled out.
+	for i := 0; i < 10; i++ {
+		p, err := puzzler.NewFibble(&puzzler.Args{WordLength: 5, Guesses: 9})
+		if err != nil {
+			t.Fatal(err)
+		}
+		s := From(wordler.Dictionary)
+		s.Tolerate(1)
+		var response string
+		for p.Guesses() > 0 && response != "+++++" {
+			guess := s.Guess()
+			if response, err = p.Guess(guess); err != nil {
+				t.Fatalf("%s: %v", guess, err)
+			}
+			if err := s.React(guess, response); err != nil {
+				t.Fatal(err)
+			}
+		}
+		solution := p.GiveUp()
+		if response != "+++++" {
+			if e, err := s.Why(solution); err == nil {
+				t.Errorf("solution %v", e)
+			}
+		}
+	}
+}
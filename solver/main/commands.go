@@ -0,0 +1,148 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"wordler"
+	"wordler/solver"
+)
+
+// pageSize is the number of words per page printed by list.
+var pageSize = 50
+
+// maxCorrections is the number of corrections suggested when no words fit the
+// responses.
+const maxCorrections = 5
+
+// why explains why word isn't a possible solution.
+func why(s *solver.Solver, word string) {
+	e, err := s.Why(word)
+	switch {
+	case errors.Is(err, solver.NotEliminatedErr):
+		fmt.Printf("'%s' is still a possible solution.\n", word)
+	case err != nil:
+		fmt.Println("ERROR: ", err)
+	default:
+		fmt.Println(e)
+	}
+}
+
+// list prints a page of possible solutions in alphabetical order. args is the
+// command line "list [-w] [page|all]"; -w includes each word's weight: its
+// frequency if the solver has frequencies, and otherwise its letter-frequency
+// score.
+func list(s *solver.Solver, args []string) {
+	weighted := false
+	page, all := 1, false
+	for _, arg := range args[1:] {
+		switch arg {
+		case "-w":
+			weighted = true
+		case "all":
+			all = true
+		default:
+			p, err := strconv.Atoi(arg)
+			if err != nil || p < 1 {
+				fmt.Println("Usage: list [-w] [page|all]")
+				return
+			}
+			page = p
+		}
+	}
+
+	words := s.Candidates()
+	first, last := 0, len(words)
+	if !all && pageSize > 0 {
+		first, last = (page-1)*pageSize, page*pageSize
+		if first >= len(words) {
+			fmt.Printf("There are only %d pages of %d words.\n", (len(words)+pageSize-1)/pageSize, pageSize)
+			return
+		}
+		if last > len(words) {
+			last = len(words)
+		}
+	}
+
+	var weight func(word string) string
+	switch freq := s.Frequencies(); {
+	case !weighted:
+		fmt.Printf("Possible words %d-%d of %d:\n", first+1, last, len(words))
+	case freq != nil:
+		fmt.Printf("Possible words %d-%d of %d, with their frequencies:\n", first+1, last, len(words))
+		weight = func(word string) string { return fmt.Sprintf("%.3g", freq[word]) }
+	default:
+		fmt.Printf("Possible words %d-%d of %d, with their letter-frequency scores:\n", first+1, last, len(words))
+		scores := s.Weights()
+		weight = func(word string) string { return strconv.Itoa(scores[word]) }
+	}
+	perLine := 8
+	if weight != nil {
+		perLine = 4
+	}
+	var line []string
+	for i, word := range words[first:last] {
+		if weight != nil {
+			word = fmt.Sprintf("%s (%s)", word, weight(word))
+		}
+		line = append(line, word)
+		if len(line) == perLine || i == last-first-1 {
+			fmt.Println("  " + strings.Join(line, "  "))
+			line = nil
+		}
+	}
+	if last < len(words) {
+		fmt.Printf("Enter 'list %d' for more.\n", page+1)
+	}
+}
+
+// suggest explains which responses contradict each other and how correcting
+// one mark could fix them.
+func suggest(s *solver.Solver) {
+	conflict := s.Conflict()
+	if len(conflict) == 0 {
+		return
+	}
+	rows := s.Rows()
+	fmt.Println("These responses contradict each other:")
+	for _, r := range conflict {
+		fmt.Printf("  row %d: %s:%s\n", r, rows[r-1].Guess, rows[r-1].Response)
+	}
+	corrections := s.Corrections()
+	if len(corrections) == 0 {
+		fmt.Println("No single mark can be corrected to fix them.")
+		return
+	}
+	if len(corrections) > maxCorrections {
+		corrections = corrections[:maxCorrections]
+	}
+	fmt.Println("Perhaps one was mistyped:")
+	for _, c := range corrections {
+		fmt.Printf("  %v: enter 'fix %d %s'\n", c, c.Row, c.Response)
+	}
+}
+
+// fix corrects an earlier response. args is the command line "fix <row>
+// <response>"; it returns true if the response was corrected.
+func fix(s *solver.Solver, args []string) bool {
+	if len(args) != 3 {
+		fmt.Println("Usage: fix <row> <response>")
+		return false
+	}
+	row, err := strconv.Atoi(args[1])
+	if err != nil || row < 1 || row > len(s.Rows()) {
+		fmt.Printf("There's no row %s; there are %d rows.\n", args[1], len(s.Rows()))
+		return false
+	}
+	response, err := wordler.ParseResponse(args[2], len([]rune(s.Rows()[row-1].Guess)))
+	if err == nil {
+		err = s.Correct(row, response)
+	}
+	if err != nil {
+		fmt.Println("ERROR: ", err)
+		return false
+	}
+	return true
+}
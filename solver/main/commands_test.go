@@ -0,0 +1,49 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"wordler/solver"
+	"wordler/wordlist"
+)
+
+func TestList(t *testing.T) {
+	words := []string{"crane", "slate", "sloth"}
+	freq := wordlist.FrequencyOption{Freq: map[string]float64{"crane": 5, "slate": 2, "sloth": 0.5}}
+	cases := []struct {
+		desc string
+		s    *solver.Solver
+		args []string
+		out  []string // lines the output must contain
+	}{
+		{
+			desc: "words",
+			s:    solver.From(words),
+			args: []string{"list"},
+			out:  []string{"Possible words 1-3 of 3:", "  crane  slate  sloth"},
+		},
+		{
+			desc: "letter-frequency scores",
+			s:    solver.From(words),
+			args: []string{"list", "-w"},
+			out:  []string{"with their letter-frequency scores:", "crane (7)", "sloth (8)"},
+		},
+		{
+			desc: "frequencies",
+			s:    solver.From(words, freq),
+			args: []string{"list", "-w"},
+			out:  []string{"with their frequencies:", "crane (5)", "sloth (0.5)"},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.desc, func(t *testing.T) {
+			out := capture(t, "", func() { list(c.s, c.args) })
+			for _, line := range c.out {
+				if !strings.Contains(out, line) {
+					t.Errorf("want output containing %q, got %s", line, out)
+				}
+			}
+		})
+	}
+}
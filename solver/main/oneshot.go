@@ -0,0 +1,64 @@
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"os"
+	"strings"
+
+	"wordler"
+	"wordler/solver"
+)
+
+// oneshot applies rows to s, taking them from stdin if there are none, then
+// prints the possible words and the next guess. It returns the exit status: 0
+// if any words remain, 1 if none do, because rows contradict each other, and
+// 2 if a row can't be parsed.
+func oneshot(s *solver.Solver, args []string) int {
+	if len(args) == 0 {
+		in := bufio.NewScanner(os.Stdin)
+		for in.Scan() {
+			line := strings.TrimSpace(in.Text())
+			switch {
+			case line == "":
+			case strings.Contains(line, ":"):
+				// Possibly several rows, e.g. "crane:__+*_ sloth:_+___".
+				args = append(args, strings.Fields(line)...)
+			default:
+				args = append(args, line)
+			}
+		}
+	}
+
+	rows := make([]wordler.Row, len(args))
+	for i, arg := range args {
+		row, err := wordler.ParseRow(arg)
+		if err != nil {
+			fmt.Println("ERROR: ", err)
+			return 2
+		}
+		rows[i] = row
+	}
+	for i, row := range rows {
+		// Rows that parse only fail to apply if they don't fit the rows
+		// before them, e.g. a guess of another length.
+		if err := s.React(row.Guess, row.Response); err != nil {
+			fmt.Printf("Row %d doesn't fit the rows before it: %v\n", i+1, err)
+			fmt.Println("No possible words remain.")
+			suggest(s)
+			return 1
+		}
+	}
+
+	if s.Remaining() == 0 {
+		fmt.Println("No possible words remain.")
+		suggest(s)
+		return 1
+	}
+	fmt.Printf("%d possible words:\n", s.Remaining())
+	for _, word := range s.Candidates() {
+		fmt.Println("  " + word)
+	}
+	fmt.Println("Next guess: " + s.Guess())
+	return 0
+}
@@ -0,0 +1,109 @@
+package main
+
+import (
+	"io"
+	"os"
+	"strings"
+	"testing"
+
+	"wordler/solver"
+)
+
+// capture returns what f prints to stdout while reading stdin from in.
+func capture(t *testing.T, in string, f func()) string {
+	t.Helper()
+	stdin, stdout := os.Stdin, os.Stdout
+	defer func() { os.Stdin, os.Stdout = stdin, stdout }()
+
+	inR, inW, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	outR, outW, err := os.Pipe()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := inW.WriteString(in); err != nil {
+		t.Fatal(err)
+	}
+	inW.Close()
+	os.Stdin, os.Stdout = inR, outW
+
+	out := make(chan string)
+	go func() {
+		b, _ := io.ReadAll(outR)
+		out <- string(b)
+	}()
+	f()
+	outW.Close()
+	return <-out
+}
+
+func TestOneshot(t *testing.T) {
+	words := []string{"crane", "crate", "trace", "slate", "sloth", "plate"}
+	cases := []struct {
+		desc  string
+		args  []string
+		stdin string
+		want  int
+		out   []string // lines the output must contain
+	}{
+		{
+			desc: "no rows",
+			want: 0,
+			out:  []string{"6 possible words:", "  sloth", "Next guess: "},
+		},
+		{
+			desc: "rows",
+			args: []string{"crane:__+_+", "slate:_++++"},
+			want: 0,
+			out:  []string{"1 possible words:", "  plate", "Next guess: plate"},
+		},
+		{
+			desc:  "rows from stdin",
+			stdin: "crane:__+_+ slate:_++++\n\n",
+			want:  0,
+			out:   []string{"1 possible words:", "Next guess: plate"},
+		},
+		{
+			desc: "contradiction",
+			args: []string{"slate:_++++", "plate:_++++"},
+			want: 1,
+			out:  []string{"No possible words remain.", "These responses contradict each other:", "  row 1: slate:_++++", "  row 2: plate:_++++"},
+		},
+		{
+			desc: "another length",
+			args: []string{"crane:+____", "cranes:______"},
+			want: 1,
+			out:  []string{"Row 2 doesn't fit the rows before it:", "No possible words remain."},
+		},
+		{
+			desc: "invalid response",
+			args: []string{"crane:+_"},
+			want: 2,
+			out:  []string{"ERROR: "},
+		},
+		{
+			desc: "invalid row",
+			args: []string{"crane:__+_+", "slate"},
+			want: 2,
+			out:  []string{"ERROR: "},
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.desc, func(t *testing.T) {
+			var got int
+			out := capture(t, c.stdin, func() {
+				got = oneshot(solver.From(words), c.args)
+			})
+			if c.want != got {
+				t.Errorf("want status %d, got %d: %s", c.want, got, out)
+			}
+			for _, line := range c.out {
+				if !strings.Contains(out, line) {
+					t.Errorf("want output containing %q, got %s", line, out)
+				}
+			}
+		})
+	}
+}
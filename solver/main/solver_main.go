@@ -1,6 +1,7 @@
 package main
 
 import (
+	"bufio"
 	"flag"
 	"fmt"
 	"os"
@@ -8,61 +9,161 @@ import (
 	"strings"
 
 	"wordler"
+	"wordler/language"
 	"wordler/solver"
 	"wordler/wordlist"
 )
 
 func main() {
 	local := flag.Bool("local_dictionary", false, "use local dictionary in place of Wordle dictionary")
+	dictionary := flag.String("dictionary", "", "load words from this file, one per line (optionally gzip-compressed), in place of Wordle dictionary")
 	length := flag.Int("length", wordler.DEFAULT_WORD_LENGTH, "word length")
 	guesses := flag.Uint("guesses", wordler.DEFAULT_GUESSES, "number of guesses allowed")
+	showBelow := flag.Int("show-candidates-below", 0, "list the possible words whenever fewer than this many remain")
+	flag.IntVar(&pageSize, "page-size", pageSize, "number of words per page for the 'list' command")
+	solve := flag.Bool("oneshot", false, "non-interactive: apply the given rows, print the possible words and my next guess, and exit")
+	lies := flag.Int("lies", 0, "allow up to this many wrong marks in each response, as in Fibble (1)")
+	export := flag.String("export-tree", "", "non-interactive: print the complete decision tree as 'json' or 'dot', or its first guesses as an opening 'book', and exit")
+	depth := flag.Int("book-depth", 2, "with --export-tree=book, the number of guesses to export from the tree: 1 for the opening guess, 2 to add each second guess, and so on")
+	bookFile := flag.String("book", "", "make the guesses in this opening book, written by --export-tree=book, before computing any")
+	treeFile := flag.String("tree", "", "look guesses up in this decision tree, written by --export-tree=json, rather than computing them")
+	optimal := flag.String("optimal", "", "non-interactive: search for the strategy starting with this guess that takes the fewest guesses in total, compare it to mine, and exit; with --export-tree, export it")
+	var search solver.Search
+	flag.IntVar(&search.Breadth, "breadth", 0, "with --optimal, try only this many guesses at each step; 0 tries all of them, which can take very long")
+	flag.BoolVar(&search.AnyGuess, "any-guess", false, "with --optimal, allow guesses that can't be the solution")
+	code := flag.String("lang", language.DEFAULT, fmt.Sprintf("language: one of %s", strings.Join(language.Codes(), ", ")))
 	usage := flag.Usage
 	flag.Usage = func() {
 		usage()
 		fmt.Fprintf(flag.CommandLine.Output(), "\nRemaining positional arguments are taken as guesses to feed to solver.\n")
+		fmt.Fprintf(flag.CommandLine.Output(), "\nWith --oneshot, positional arguments are rows like 'crane:__+*_'; without\n")
+		fmt.Fprintf(flag.CommandLine.Output(), "any, rows are read from stdin. Exit status is 0 if any words remain, 1 if\n")
+		fmt.Fprintf(flag.CommandLine.Output(), "none do, and 2 for invalid input.\n")
 	}
 	flag.Parse()
 
-	fmt.Printf("I'm a wordle solver! I'll make up to %d guesses, you tell me wordle's response.\n", *guesses)
-	if *local {
-		fmt.Printf("I only allow %d-letter words found in the local dictionary.\n", *length)
+	lang, err := language.Get(*code)
+	if err != nil {
+		fmt.Println("ERROR: ", err)
+		os.Exit(2)
+	}
+
+	var tree *solver.Tree
+	if *treeFile != "" {
+		if tree, err = loadTree(*treeFile); err != nil {
+			fmt.Println("ERROR: ", err)
+			os.Exit(2)
+		}
+	}
+	var book solver.Book
+	if *bookFile != "" {
+		if book, err = solver.LoadBook(*bookFile); err != nil {
+			fmt.Println("ERROR: ", err)
+			os.Exit(2)
+		}
+	}
+
+	if *export != "" {
+		s, err := newSolver(lang, *local, *dictionary, *length)
+		if err != nil {
+			fmt.Printf("Failed to make a Solver: %v\n", err)
+			os.Exit(2)
+		}
+		s.Follow(tree)
+		s.UseBook(book)
+		os.Exit(exportTree(s, *export, *optimal, search, *depth))
+	}
+
+	if *optimal != "" {
+		s, err := newSolver(lang, *local, *dictionary, *length)
+		if err != nil {
+			fmt.Printf("Failed to make a Solver: %v\n", err)
+			os.Exit(2)
+		}
+		s.Follow(tree)
+		s.UseBook(book)
+		os.Exit(compare(s, *optimal, search))
+	}
+
+	if *solve {
+		s, err := newSolver(lang, *local, *dictionary, *length)
+		if err != nil {
+			fmt.Printf("Failed to make a Solver: %v\n", err)
+			os.Exit(2)
+		}
+		s.Tolerate(*lies)
+		s.Follow(tree)
+		s.UseBook(book)
+		os.Exit(oneshot(s, flag.Args()))
+	}
+
+	fmt.Printf(lang.T("I'm a wordle solver! I'll make up to %d guesses, you tell me wordle's response.\n"), *guesses)
+	if *dictionary != "" {
+		fmt.Printf(lang.T("I only allow %d-letter words found in %s.\n"), *length, *dictionary)
+	} else if *local {
+		fmt.Printf(lang.T("I only allow %d-letter words found in the local dictionary.\n"), *length)
+	} else if lang.Loader() != nil {
+		fmt.Printf(lang.T("I only allow %d-letter words in %s.\n"), *length, lang.Name)
 	} else if *length != wordler.DEFAULT_WORD_LENGTH && *length != 0 {
-		fmt.Printf("I'm ignoring the --length=%d flag, using Worlde dictionary\n", *length)
+		fmt.Printf(lang.T("I only allow %d-letter words from my bundled word lists.\n"), *length)
 	}
-	fmt.Printf("Use '%c' for \"right letter in the right place\"\n", wordler.CORRECT)
-	fmt.Printf("Use '%c' for \"right letter in the wrong place\"\n", wordler.ELSEWHERE)
-	fmt.Printf("Use '%c' for \"letter not in the word\"\n", wordler.NIL)
-	fmt.Println("Respond with the letter 'n' by itself to tell me that my guess isn't in wordle's dictionary.")
-	fmt.Println("Respond with the letter 'y' by itself to tell me that I've solved the wordle.")
-	fmt.Println("Ready? Here we go!")
+	fmt.Printf(lang.T("Use '%c' for \"right letter in the right place\"\n"), wordler.CORRECT)
+	fmt.Printf(lang.T("Use '%c' for \"right letter in the wrong place\"\n"), wordler.ELSEWHERE)
+	fmt.Printf(lang.T("Use '%c' for \"letter not in the word\"\n"), wordler.NIL)
+	fmt.Println(lang.T("You can also use colors (\"gyb\" or \"gyx\"), emoji squares (🟩🟨⬛) or digits (\"210\")."))
+	fmt.Println(lang.T("Respond with the letter 'n' by itself to tell me that my guess isn't in wordle's dictionary."))
+	fmt.Println(lang.T("Respond with the letter 'y' by itself to tell me that I've solved the wordle."))
+	fmt.Println(lang.T("Respond with 'why <word>' to ask me why <word> isn't a possible solution."))
+	fmt.Println(lang.T("Respond with 'list [-w] [page]' to see the possible words, optionally with their frequencies or letter-frequency scores."))
+	fmt.Println(lang.T("Respond with 'known' to see what I know about the solution so far."))
+	fmt.Println(lang.T("Respond with 'fix <row> <response>' to correct an earlier response."))
+	if *lies > 0 {
+		fmt.Printf(lang.T("I'll allow for up to %d wrong marks in each response.\n"), *lies)
+	}
+	fmt.Println(lang.T("Ready? Here we go!"))
 	fmt.Println()
 
-	var s *solver.Solver
-	var err error
-	if *local {
-		s, err = solver.New(wordlist.KeepOnlyOption{Exp: regexp.MustCompile(fmt.Sprintf("^.{%d}$", *length))})
-	} else {
-		s = solver.From(wordler.Dictionary)
-	}
+	s, err := newSolver(lang, *local, *dictionary, *length)
 	if err != nil {
 		fmt.Printf("Failed to make a Solver: %v\n", err)
 		os.Exit(2)
 	}
+	s.Tolerate(*lies)
+	s.Follow(tree)
+	s.UseBook(book)
 
 	clGuesses := flag.Args()
+	in := bufio.NewScanner(os.Stdin)
 GUESS:
 	for *guesses > 0 {
 		switch s.Remaining() {
 		case 0:
-			fmt.Println("ERROR: solver is empty.")
-			os.Exit(1)
+			fmt.Println(lang.T("No words fit your responses."))
+			suggest(s)
+			for {
+				fmt.Print(lang.T("Correction? "))
+				if !in.Scan() {
+					fmt.Println()
+					os.Exit(1)
+				}
+				fields := strings.Fields(in.Text())
+				if len(fields) > 0 && fields[0] == "fix" && fix(s, fields) {
+					fmt.Println()
+					continue GUESS
+				} else if len(fields) > 0 && fields[0] != "fix" {
+					fmt.Println("Usage: fix <row> <response>")
+				}
+			}
 
 		case 1:
-			fmt.Println("The word is " + s.Guess())
+			fmt.Println(lang.T("The word is ") + s.Guess())
 			os.Exit(0)
 
 		default:
-			fmt.Printf("I've got %d possible words and %d guesses left.\n", s.Remaining(), *guesses)
+			fmt.Printf(lang.T("I've got %d possible words and %d guesses left.\n"), s.Remaining(), *guesses)
+			if s.Remaining() < *showBelow {
+				list(s, []string{"list", "all"})
+			}
 
 			var guess string
 			if len(clGuesses) > 0 {
@@ -71,14 +172,46 @@ GUESS:
 			} else {
 				guess = s.Guess()
 			}
-			fmt.Println("Guess: " + guess)
+			fmt.Println(lang.T("Guess: ") + guess)
 
 			for done := false; !done; {
-				var response string
-				fmt.Print("Response? ")
-				fmt.Scan(&response)
+				fmt.Print(lang.T("Response? "))
+				if !in.Scan() {
+					fmt.Println()
+					os.Exit(0)
+				}
+				fields := strings.Fields(in.Text())
+				if len(fields) == 0 {
+					continue
+				}
+				response := fields[0]
 
 				switch response {
+				case "why":
+					if len(fields) != 2 {
+						fmt.Println("Usage: why <word>")
+					} else {
+						why(s, fields[1])
+					}
+					continue
+
+				case "list":
+					list(s, fields)
+					continue
+
+				case "known":
+					fmt.Println(s.Known())
+					continue
+
+				case "fix":
+					if fix(s, fields) {
+						// Guess again with the corrected response, without
+						// using up a guess.
+						fmt.Println()
+						continue GUESS
+					}
+					continue
+
 				case "n":
 					s.NotInWordle(guess)
 					done = true
@@ -101,6 +234,24 @@ GUESS:
 		// decrement when response is "n".
 		*guesses--
 	}
-	fmt.Println("Out of guesses :-(")
+	fmt.Println(lang.T("Out of guesses :-("))
 	os.Exit(0)
 }
+
+// newSolver returns a Solver using the named dictionary file, the local
+// dictionary or lang's dictionary, limited to words of the given length, or
+// else the Wordle dictionary or the bundled word lists for that length. Words
+// are limited to lang's alphabet.
+func newSolver(lang *language.Language, local bool, dictionary string, length int) (*solver.Solver, error) {
+	options := []wordlist.Option{wordlist.KeepOnlyOption{Exp: regexp.MustCompile(fmt.Sprintf("^.{%d}$", length))}, lang.Option()}
+	if dictionary != "" {
+		return solver.Load(wordlist.NewFileLoader(dictionary), options...)
+	}
+	if local {
+		return solver.New(options...)
+	}
+	if loader := lang.Loader(); loader != nil {
+		return solver.Load(loader, options...)
+	}
+	return solver.ForLength(length, lang.Option())
+}
@@ -0,0 +1,84 @@
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"wordler/solver"
+)
+
+// exportTree builds the decision tree s follows, or if first is set, the
+// optimal tree starting with first, and writes it to stdout in the given
+// format, "json", "dot" or "book", reporting how many guesses it takes on
+// stderr. A book holds the tree's first depth guesses. It returns the exit
+// status: 0 on success and 2 on failure.
+func exportTree(s *solver.Solver, format, first string, search solver.Search, depth int) int {
+	if format != "json" && format != "dot" && format != "book" {
+		fmt.Printf("ERROR: invalid --export-tree %q: want 'json', 'dot' or 'book'\n", format)
+		return 2
+	}
+	var tree *solver.Tree
+	var err error
+	if first != "" {
+		tree, err = s.Optimal(first, search)
+	} else {
+		tree, err = s.Tree()
+	}
+	if err == nil && tree == nil {
+		err = fmt.Errorf("no possible words")
+	}
+	if err != nil {
+		fmt.Println("ERROR: ", err)
+		return 2
+	}
+	average, worst := tree.Stats()
+	fmt.Fprintf(os.Stderr, "%d words: %.3f guesses on average, %d at worst\n", tree.Words, average, worst)
+
+	switch format {
+	case "dot":
+		err = tree.WriteDot(os.Stdout)
+	case "book":
+		err = tree.Book(depth).Write(os.Stdout)
+	default:
+		err = tree.WriteJSON(os.Stdout)
+	}
+	if err != nil {
+		fmt.Println("ERROR: ", err)
+		return 2
+	}
+	return 0
+}
+
+// compare reports how many guesses s takes on average and at worst, and how
+// many the optimal strategy starting with first takes. It returns the exit
+// status: 0 on success and 2 on failure.
+func compare(s *solver.Solver, first string, search solver.Search) int {
+	tree, err := s.Tree()
+	if err != nil {
+		fmt.Println("ERROR: ", err)
+		return 2
+	}
+	optimal, err := s.Optimal(first, search)
+	if err == nil && (tree == nil || optimal == nil) {
+		err = fmt.Errorf("no possible words")
+	}
+	if err != nil {
+		fmt.Println("ERROR: ", err)
+		return 2
+	}
+	average, worst := tree.Stats()
+	fmt.Printf("Solver, starting with '%s': %.3f guesses on average, %d at worst\n", tree.Guess, average, worst)
+	average, worst = optimal.Stats()
+	fmt.Printf("Optimal, starting with '%s': %.3f guesses on average, %d at worst\n", first, average, worst)
+	return 0
+}
+
+// loadTree reads the decision tree in the named JSON file.
+func loadTree(path string) (*solver.Tree, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	return solver.ReadTree(file)
+}
@@ -0,0 +1,173 @@
+package solver
+
+import (
+	"fmt"
+	"strings"
+
+	"wordler"
+	"wordler/wordlist"
+)
+
+// searchBelow is the number of possible solutions, across all unsolved boards,
+// below which Multi considers every one of them as a guess. With more, it only
+// considers each board's own best guess.
+const searchBelow = 500
+
+// Multi solves a multi-board puzzle, like Dordle or Quordle: it keeps a Solver
+// per board and picks guesses that help the unsolved boards the most.
+type Multi struct {
+	boards []*Solver
+	solved []bool // solved[i] is true once boards[i] has been solved
+}
+
+// MultiFrom returns a new Multi for the given number of boards, each created
+// from the given list of words as From does.
+func MultiFrom(boards int, dictionary []string, options ...wordlist.Option) *Multi {
+	w := wordlist.New(dictionary, options...)
+	m := &Multi{solved: make([]bool, boards)}
+	for i := 0; i < boards; i++ {
+		m.boards = append(m.boards, &Solver{s: w.Clone(), g: w.Clone(), dict: w.Clone()})
+	}
+	return m
+}
+
+// LoadMulti returns a new Multi for the given number of boards, each
+// populated with the dictionary loaded by l as Load does.
+func LoadMulti(boards int, l wordlist.DictionaryLoader, options ...wordlist.Option) (*Multi, error) {
+	m := &Multi{solved: make([]bool, boards)}
+	for i := 0; i < boards; i++ {
+		s, err := Load(l, options...)
+		if err != nil {
+			return nil, err
+		}
+		m.boards = append(m.boards, s)
+	}
+	return m, nil
+}
+
+// MultiForLength returns a new Multi for the given number of boards, each
+// created for words of the given length as ForLength does.
+func MultiForLength(boards, length int, options ...wordlist.Option) (*Multi, error) {
+	m := &Multi{solved: make([]bool, boards)}
+	for i := 0; i < boards; i++ {
+		s, err := ForLength(length, options...)
+		if err != nil {
+			return nil, err
+		}
+		m.boards = append(m.boards, s)
+	}
+	return m, nil
+}
+
+// Guess provides a guess for the unsolved boards. If a board is down to one
+// possible solution, that's the guess; otherwise it's the guess that leaves
+// the fewest possible solutions expected, relative to how many each board has
+// now, summed over the unsolved boards.
+func (m *Multi) Guess() string {
+	var unsolved []*Solver
+	for i, s := range m.boards {
+		if !m.solved[i] {
+			unsolved = append(unsolved, s)
+		}
+	}
+	if len(unsolved) == 0 {
+		return ""
+	}
+
+	var guesses []string // guesses to consider, without duplicates
+	seen := make(map[string]bool)
+	add := func(guess string) {
+		if guess != "" && !seen[guess] {
+			seen[guess] = true
+			guesses = append(guesses, guess)
+		}
+	}
+	remaining := 0
+	for _, s := range unsolved {
+		if s.Remaining() == 1 {
+			return s.Guess()
+		}
+		remaining += s.Remaining()
+	}
+	for _, s := range unsolved {
+		add(s.Guess())
+	}
+	if remaining < searchBelow {
+		for _, s := range unsolved {
+			for _, word := range s.Candidates() {
+				add(word)
+			}
+		}
+	}
+
+	best, bestScore := "", 0.0
+	for _, guess := range guesses {
+		score := 0.0
+		for _, s := range unsolved {
+			if n := s.Remaining(); n > 0 {
+				score += s.s.ExpectedRemaining(guess) / float64(n)
+			}
+		}
+		if best == "" || score < bestScore {
+			best, bestScore = guess, score
+		}
+	}
+	return best
+}
+
+// React "reacts" to the responses to guess, one per board, as returned by
+// puzzler.Multi.Guess: solved boards have an empty response and are skipped.
+// Responses may use any notation accepted by wordler.ParseResponse.
+func (m *Multi) React(guess string, responses []string) error {
+	if len(responses) != len(m.boards) {
+		return fmt.Errorf("invalid responses %q: want one for each of %d boards", responses, len(m.boards))
+	}
+	for i, response := range responses {
+		if m.solved[i] || response == "" {
+			continue
+		}
+		if err := m.boards[i].React(guess, response); err != nil {
+			return fmt.Errorf("board %d: %w", i+1, err)
+		}
+		rows := m.boards[i].Rows()
+		response = rows[len(rows)-1].Response
+		m.solved[i] = response == strings.Repeat(string(wordler.CORRECT), len([]rune(response)))
+	}
+	return nil
+}
+
+// NotInWordle is used to report that the word is not found in the wordle
+// dictionary; the word is removed from every board.
+func (m *Multi) NotInWordle(not string) {
+	for _, s := range m.boards {
+		s.NotInWordle(not)
+	}
+}
+
+// Boards returns the number of boards.
+func (m *Multi) Boards() int {
+	return len(m.boards)
+}
+
+// Board returns the Solver for board i, counting from 0.
+func (m *Multi) Board(i int) *Solver {
+	if i < 0 || i >= len(m.boards) {
+		return nil
+	}
+	return m.boards[i]
+}
+
+// IsSolved returns true if board i, counting from 0, has been solved.
+func (m *Multi) IsSolved(i int) bool {
+	return i >= 0 && i < len(m.solved) && m.solved[i]
+}
+
+// Solved returns true if every board has been solved.
+func (m *Multi) Solved() bool {
+	for _, solved := range m.solved {
+		if !solved {
+			return false
+		}
+	}
+	return true
+}
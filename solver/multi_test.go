@@ -0,0 +1,63 @@
+package solver
+
+import (
+	"reflect"
+	"testing"
+
+	"wordler"
+	"wordler/puzzler"
+)
+
+func TestMultiGuess(t *testing.T) {
+	m := MultiFrom(2, []string{"foo", "bar", "bam", "zap", "zoo"})
+	if err := m.React("bar", []string{"+++", "_+_"}); err != nil {
+		t.Fatal(err)
+	}
+	if !m.IsSolved(0) || m.IsSolved(1) || m.Solved() {
+		t.Errorf("want only board 1 solved, got %t and %t", m.IsSolved(0), m.IsSolved(1))
+	}
+	if want, got := []string{"zap"}, m.Board(1).Candidates(); !reflect.DeepEqual(want, got) {
+		t.Errorf("want %v, got %v", want, got)
+	}
+
+	// A board with one possible solution left is finished first.
+	m = MultiFrom(2, []string{"foo", "bar", "bam", "zap", "zoo"})
+	if err := m.React("zoo", []string{"___", "+__"}); err != nil {
+		t.Fatal(err)
+	}
+	if want, got := "zap", m.Guess(); want != got {
+		t.Errorf("want %q, got %q", want, got)
+	}
+
+	if err := m.React("zap", []string{"___"}); err == nil {
+		t.Error("want error for too few responses")
+	}
+}
+
+func TestMultiGame(t *testing.T) {
+	for _, boards := range []int{2, 4} {
+		p, err := puzzler.NewMulti(nil, boards)
+		if err != nil {
+			t.Fatal(err)
+		}
+		m := MultiFrom(boards, wordler.Dictionary)
+		for p.Guesses() > 0 && !p.Solved() {
+			guess := m.Guess()
+			responses, err := p.Guess(guess)
+			if err != nil {
+				t.Fatalf("guess %q: %v", guess, err)
+			}
+			if err := m.React(guess, responses); err != nil {
+				t.Fatalf("guess %q, responses %q: %v", guess, responses, err)
+			}
+			for i := 0; i < boards; i++ {
+				if m.IsSolved(i) != p.IsSolved(i) {
+					t.Errorf("board %d: solver says solved %t, puzzler %t", i+1, m.IsSolved(i), p.IsSolved(i))
+				}
+			}
+		}
+		if m.Solved() != p.Solved() {
+			t.Errorf("%d boards: solver says solved %t, puzzler %t", boards, m.Solved(), p.Solved())
+		}
+	}
+}
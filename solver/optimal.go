@@ -0,0 +1,292 @@
+package solver
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"unicode/utf8"
+
+	"wordler"
+)
+
+// Search configures Optimal.
+type Search struct {
+	// Breadth is the number of guesses tried at each step, those that split
+	// the possible solutions into the most groups first. 0 tries every guess,
+	// which makes the strategy optimal but may take very long for large
+	// dictionaries.
+	Breadth int
+	// AnyGuess allows guessing any word in the Solver's dictionary, not only
+	// possible solutions as Guess does.
+	AnyGuess bool
+}
+
+// Optimal returns the decision tree that, starting with first, takes the
+// fewest guesses in total to solve every possible solution. Its Stats give
+// the optimum to compare the Solver's own Tree against.
+//
+// Optimal searches every strategy, within the limits set by search, pruning
+// any that can't beat the best found so far: a group of n possible solutions
+// takes at least 2n-1 guesses in total, since at most one of them is solved
+// by the next guess. It remembers the best strategy for each group of
+// possible solutions, which many paths through the tree lead to.
+func (s *Solver) Optimal(first string, search Search) (*Tree, error) {
+	if s == nil || s.Remaining() == 0 {
+		return nil, nil
+	}
+	if s.lies > 0 {
+		return nil, LiesErr
+	}
+	words := s.Candidates()
+	if n := utf8.RuneCountInString(first); n != utf8.RuneCountInString(words[0]) {
+		return nil, fmt.Errorf("'%s' has %d letters, want %d", first, n, utf8.RuneCountInString(words[0]))
+	}
+	// Each response to first leaves a group of words that is solved on its
+	// own, so each gets an optimizer of its own, with a smaller table.
+	buckets := make(map[string][]string)
+	for _, word := range words {
+		response := wordler.Score(first, word)
+		buckets[response] = append(buckets[response], word)
+	}
+	t := &Tree{Guess: first, Words: len(words)}
+	win := strings.Repeat(string(wordler.CORRECT), utf8.RuneCountInString(first))
+	for response, bucket := range buckets {
+		if response == win {
+			continue
+		}
+		if len(bucket) == len(words) {
+			return nil, fmt.Errorf("'%s' %w", first, NoProgressErr)
+		}
+		var others []string // words that may be guessed but can't be the solution
+		if search.AnyGuess {
+			in := make(map[string]bool, len(bucket))
+			for _, word := range bucket {
+				in[word] = true
+			}
+			guessable := s.dict
+			if s.all != nil {
+				guessable = s.all
+			}
+			for _, word := range guessable.Sorted() {
+				if !in[word] && !s.not[word] {
+					others = append(others, word)
+				}
+			}
+		}
+		o := newOptimizer(bucket, others, search.Breadth)
+		all := make([]int, len(bucket))
+		for i := range all {
+			all[i] = i
+		}
+		o.solve(all, math.MaxInt32)
+		if t.Next == nil {
+			t.Next = make(map[string]*Tree)
+		}
+		t.Next[response] = o.tree(all)
+	}
+	return t, nil
+}
+
+// optimizer searches for the strategy that solves a group of words in the
+// fewest guesses in total. Words are referred to by their index in words, and
+// guesses by their index in guesses, which starts with words.
+type optimizer struct {
+	breadth int
+	words   []string
+	guesses []string
+	codes   [][]uint16        // codes[g][w] encodes the response to guesses[g] when words[w] is the solution
+	marks   map[uint16]string // the response each code encodes
+	win     uint16            // the code of a win
+
+	exact map[string]result // the best strategy for each group of words searched
+	lower map[string]int    // a lower bound on the guesses for groups with no strategy found
+}
+
+// result is the best strategy found for a group of words: cost guesses in
+// total, starting with guesses[guess].
+type result struct {
+	cost, guess int
+}
+
+// newOptimizer returns an optimizer for words, which may also guess others.
+func newOptimizer(words, others []string, breadth int) *optimizer {
+	o := &optimizer{
+		breadth: breadth,
+		words:   words,
+		guesses: append(append([]string{}, words...), others...),
+		marks:   make(map[uint16]string),
+		exact:   make(map[string]result),
+		lower:   make(map[string]int),
+	}
+	o.codes = make([][]uint16, len(o.guesses))
+	for g, guess := range o.guesses {
+		o.codes[g] = make([]uint16, len(words))
+		for w, word := range words {
+			response := wordler.Score(guess, word)
+			code := uint16(0)
+			for _, mark := range response {
+				code *= 3
+				switch mark {
+				case wordler.CORRECT:
+					code += 2
+				case wordler.ELSEWHERE:
+					code++
+				}
+			}
+			o.codes[g][w] = code
+			o.marks[code] = response
+			if g == w {
+				o.win = code
+			}
+		}
+	}
+	return o
+}
+
+// minimum returns the fewest guesses n words can take in total: one of them
+// may be solved by the next guess, but the rest take at least two.
+func minimum(n int) int {
+	return 2*n - 1
+}
+
+// option is a guess to try and the least it can cost.
+type option struct {
+	guess, lower int
+}
+
+// solve returns the fewest guesses that solve the group of words, in total,
+// if that's less than limit; otherwise it returns a lower bound that is at
+// least limit.
+func (o *optimizer) solve(group []int, limit int) int {
+	n := len(group)
+	switch n {
+	case 1:
+		return 1
+	case 2:
+		// Guess either; the other takes a second guess if it's the solution.
+		return 3
+	}
+	key := groupKey(group)
+	if r, ok := o.exact[key]; ok {
+		return r.cost
+	}
+	if lower := o.lower[key]; lower >= limit {
+		return lower
+	}
+
+	// Rank the guesses by the least each can cost.
+	pool := group
+	if len(o.guesses) > len(o.words) {
+		pool = make([]int, len(o.guesses))
+		for g := range pool {
+			pool[g] = g
+		}
+	}
+	counts := make(map[uint16]int)
+	var options []option
+	for _, g := range pool {
+		for k := range counts {
+			delete(counts, k)
+		}
+		for _, w := range group {
+			counts[o.codes[g][w]]++
+		}
+		lower := n
+		for code, c := range counts {
+			if code != o.win {
+				lower += minimum(c)
+			}
+		}
+		if counts[o.win] == 0 && len(counts) == 1 {
+			continue // no progress
+		}
+		options = append(options, option{guess: g, lower: lower})
+	}
+	sort.SliceStable(options, func(i, j int) bool {
+		return options[i].lower < options[j].lower
+	})
+	if o.breadth > 0 && len(options) > o.breadth {
+		options = options[:o.breadth]
+	}
+
+	best, bestGuess := limit, -1
+	for _, opt := range options {
+		if opt.lower >= best {
+			break
+		}
+		buckets := o.buckets(opt.guess, group)
+		total := opt.lower
+		for _, bucket := range buckets {
+			total -= minimum(len(bucket))
+			total += o.solve(bucket, best-total)
+			if total >= best {
+				break
+			}
+		}
+		if total < best {
+			best, bestGuess = total, opt.guess
+			if best == minimum(n) {
+				break // nothing can do better
+			}
+		}
+	}
+	if bestGuess < 0 {
+		o.lower[key] = best
+		return best
+	}
+	o.exact[key] = result{cost: best, guess: bestGuess}
+	return best
+}
+
+// buckets groups the words in group by their response to guess, leaving out
+// a win, largest group first.
+func (o *optimizer) buckets(guess int, group []int) [][]int {
+	by := make(map[uint16][]int)
+	for _, w := range group {
+		if w != guess {
+			by[o.codes[guess][w]] = append(by[o.codes[guess][w]], w)
+		}
+	}
+	buckets := make([][]int, 0, len(by))
+	for _, bucket := range by {
+		buckets = append(buckets, bucket)
+	}
+	sort.Slice(buckets, func(i, j int) bool {
+		if len(buckets[i]) != len(buckets[j]) {
+			return len(buckets[i]) > len(buckets[j])
+		}
+		return buckets[i][0] < buckets[j][0]
+	})
+	return buckets
+}
+
+// tree returns the decision tree of the best strategy found for group.
+func (o *optimizer) tree(group []int) *Tree {
+	t := &Tree{Words: len(group)}
+	var guess int
+	switch len(group) {
+	case 1, 2:
+		guess = group[0]
+	default:
+		guess = o.exact[groupKey(group)].guess
+	}
+	t.Guess = o.guesses[guess]
+	for _, bucket := range o.buckets(guess, group) {
+		if t.Next == nil {
+			t.Next = make(map[string]*Tree)
+		}
+		t.Next[o.marks[o.codes[guess][bucket[0]]]] = o.tree(bucket)
+	}
+	return t
+}
+
+// groupKey returns a key identifying group, whose indexes are in increasing
+// order.
+func groupKey(group []int) string {
+	b := make([]byte, 0, 3*len(group))
+	for _, w := range group {
+		b = append(b, byte(w>>16), byte(w>>8), byte(w))
+	}
+	return string(b)
+}
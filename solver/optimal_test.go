@@ -0,0 +1,143 @@
+package solver
+
+import (
+	"errors"
+	"testing"
+
+	"wordler"
+)
+
+var aterWords = []string{"baker", "cater", "eater", "gamer", "hater", "later", "maker", "rater", "taker", "tamer", "wafer", "water"}
+
+// fewest returns the fewest guesses that solve words in total, guessing only
+// among them, by trying every strategy.
+func fewest(words []string) int {
+	if len(words) == 1 {
+		return 1
+	}
+	best := -1
+	for _, guess := range words {
+		buckets := make(map[string][]string)
+		for _, word := range words {
+			if word != guess {
+				response := wordler.Score(guess, word)
+				buckets[response] = append(buckets[response], word)
+			}
+		}
+		total := len(words)
+		for _, bucket := range buckets {
+			total += fewest(bucket)
+		}
+		if best < 0 || total < best {
+			best = total
+		}
+	}
+	return best
+}
+
+func TestOptimal(t *testing.T) {
+	for _, first := range []string{"baker", "water", "gamer"} {
+		tree, err := From(aterWords).Optimal(first, Search{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if tree.Guess != first {
+			t.Errorf("want first guess %q, got %q", first, tree.Guess)
+		}
+
+		// The best total is the first guess for every word, plus the fewest
+		// guesses for each group of words it leaves.
+		want := len(aterWords)
+		buckets := make(map[string][]string)
+		for _, word := range aterWords {
+			if word != first {
+				buckets[wordler.Score(first, word)] = append(buckets[wordler.Score(first, word)], word)
+			}
+		}
+		for _, bucket := range buckets {
+			want += fewest(bucket)
+		}
+		guesses := tree.Guesses()
+		got := 0
+		for _, n := range guesses {
+			got += n
+		}
+		if len(guesses) != len(aterWords) || want != got {
+			t.Errorf("%s: want %d guesses in total, got %d for %v", first, want, got, guesses)
+		}
+
+		// Playing by the tree takes as many guesses as it says.
+		for _, word := range aterWords {
+			s := From(aterWords)
+			s.Follow(tree)
+			n := 1
+			for guess := s.Guess(); guess != word; guess = s.Guess() {
+				if err := s.React(guess, wordler.Score(guess, word)); err != nil {
+					t.Fatal(err)
+				}
+				n++
+			}
+			if guesses[word] != n {
+				t.Errorf("%s: '%s' took %d guesses, want %d", first, word, n, guesses[word])
+			}
+		}
+	}
+}
+
+func TestOptimalBeatsTree(t *testing.T) {
+	s := From(aterWords)
+	tree, err := s.Tree()
+	if err != nil {
+		t.Fatal(err)
+	}
+	want, _ := tree.Stats()
+	for _, search := range []Search{{}, {AnyGuess: true}} {
+		optimal, err := s.Optimal(tree.Guess, search)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got, _ := optimal.Stats(); got > want {
+			t.Errorf("%+v: want at most %v guesses on average, got %v", search, want, got)
+		}
+	}
+}
+
+func TestOptimalAnyGuess(t *testing.T) {
+	s := From([]string{"bat", "cat", "hat", "mat", "pat", "chm"})
+	if err := s.React("zat", "_++"); err != nil {
+		t.Fatal(err)
+	}
+	// After "bat", guessing "cat", "hat", "mat" or "pat" leaves three words
+	// to tell apart, but "chm", which can't be the solution, tells them all
+	// apart at once.
+	only, err := s.Optimal("bat", Search{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	anyGuess, err := s.Optimal("bat", Search{AnyGuess: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, got := "chm", anyGuess.Next["_++"].Guess; want != got {
+		t.Errorf("want %q, got %q", want, got)
+	}
+	a, _ := anyGuess.Stats()
+	o, _ := only.Stats()
+	if a >= o {
+		t.Errorf("want fewer guesses than %v on average, got %v", o, a)
+	}
+}
+
+func TestOptimalErrors(t *testing.T) {
+	s := From(aterWords)
+	if _, err := s.Optimal("bake", Search{}); err == nil {
+		t.Error("want error for a guess of the wrong length")
+	}
+	if _, err := s.Optimal("zzzzz", Search{}); !errors.Is(err, NoProgressErr) {
+		t.Errorf("want %v, got %v", NoProgressErr, err)
+	}
+	s.Tolerate(1)
+	if _, err := s.Optimal("baker", Search{}); !errors.Is(err, LiesErr) {
+		t.Errorf("want %v, got %v", LiesErr, err)
+	}
+}
@@ -0,0 +1,190 @@
+package solver
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"wordler"
+)
+
+// Correction is a change to one mark of one response. When no words fit the
+// responses, a mistyped mark is the likeliest reason, and correcting it leaves
+// some words possible.
+type Correction struct {
+	Row       int    // 1-based index of the row to correct
+	Position  int    // 1-based position of the mark to change
+	Was, Mark rune   // the mark entered and the mark suggested in its place
+	Response  string // the corrected response
+	Remaining int    // how many possible solutions the correction leaves
+}
+
+// String fulfills the fmt.Stringer interface.
+func (c Correction) String() string {
+	words := fmt.Sprintf("%d words remain", c.Remaining)
+	if c.Remaining == 1 {
+		words = "1 word remains"
+	}
+	return fmt.Sprintf("if row %d position %d was %s instead of %s, %s", c.Row, c.Position, color(c.Mark), color(c.Was), words)
+}
+
+// color returns the name of the color Wordle shows for mark.
+func color(mark rune) string {
+	switch mark {
+	case wordler.CORRECT:
+		return "green"
+	case wordler.ELSEWHERE:
+		return "yellow"
+	case wordler.NIL:
+		return "gray"
+	}
+	return fmt.Sprintf("'%c'", mark)
+}
+
+// Conflict returns the 1-based indexes of a smallest set of rows whose
+// responses contradict each other: no word fits all of them, but words fit
+// any of them left out. Conflict returns nil if words fit every row.
+func (s *Solver) Conflict() []int {
+	if s == nil {
+		return nil
+	}
+	words, fits := s.fits()
+	possible := func(rows []int) bool {
+	WORDS:
+		for i := range words {
+			for _, r := range rows {
+				if !fits[r-1][i] {
+					continue WORDS
+				}
+			}
+			return true
+		}
+		return false
+	}
+
+	var rows []int
+	for r := range s.rows {
+		rows = append(rows, r+1)
+	}
+	if possible(rows) {
+		return nil
+	}
+	// Leave out each row in turn, keeping it only if the rest stop
+	// contradicting each other without it.
+	for i := 0; i < len(rows); {
+		without := append(append([]int{}, rows[:i]...), rows[i+1:]...)
+		if possible(without) {
+			i++
+		} else {
+			rows = without
+		}
+	}
+	return rows
+}
+
+// Corrections returns every change of one mark in one response that leaves
+// possible solutions, likeliest first. A correction is likelier the more
+// words it leaves, because each of them could be the solution.
+func (s *Solver) Corrections() []Correction {
+	if s == nil {
+		return nil
+	}
+	words, fits := s.fits()
+	misfits := make([]int, len(words)) // how many rows each word doesn't fit
+	misfit := make([]int, len(words))  // the row each word doesn't fit, if only one
+	for r := range s.rows {
+		for i := range words {
+			if !fits[r][i] {
+				misfits[i]++
+				misfit[i] = r
+			}
+		}
+	}
+
+	var corrections []Correction
+	for r, row := range s.rows {
+		response := []rune(row.Response)
+		for p, was := range response {
+			for _, mark := range []rune{wordler.CORRECT, wordler.ELSEWHERE, wordler.NIL} {
+				if mark == was {
+					continue
+				}
+				response[p] = mark
+				corrected := wordler.Row{Guess: row.Guess, Response: string(response)}
+				response[p] = was
+
+				n := 0
+				for i, word := range words {
+					if (misfits[i] == 0 || misfits[i] == 1 && misfit[i] == r) && s.fit(corrected, word) {
+						n++
+					}
+				}
+				if n > 0 {
+					corrections = append(corrections, Correction{Row: r + 1, Position: p + 1, Was: was, Mark: mark, Response: corrected.Response, Remaining: n})
+				}
+			}
+		}
+	}
+	sort.SliceStable(corrections, func(i, j int) bool {
+		return corrections[i].Remaining > corrections[j].Remaining
+	})
+	return corrections
+}
+
+// Correct replaces the response to row, counting from 1, and reacts to every
+// row again as if the corrected response had been entered in the first place.
+func (s *Solver) Correct(row int, response string) error {
+	if s == nil || row < 1 || row > len(s.rows) {
+		return fmt.Errorf("invalid row %d: there are %d rows", row, len(s.Rows()))
+	}
+	rows := s.Rows()
+	rows[row-1].Response = response
+	replay := &Solver{s: s.dict.Clone(), g: s.dict.Clone(), dict: s.dict.Clone(), all: s.all, lies: s.lies, root: s.root, tree: s.root, book: s.book}
+	if s.all != nil {
+		replay.g = s.all.Clone()
+	}
+	for i, r := range rows {
+		if err := replay.React(r.Guess, r.Response); err != nil {
+			return fmt.Errorf("row %d: %w", i+1, err)
+		}
+	}
+	for not := range s.not {
+		replay.NotInWordle(not)
+	}
+	for word := range s.refused {
+		replay.Refused(word)
+	}
+	*s = *replay
+	return nil
+}
+
+// fits returns the words the solver started with, less any reported as not in
+// wordle, and whether each fits each row: fits[r][i] is true if words[i] fits
+// row r.
+func (s *Solver) fits() ([]string, [][]bool) {
+	var words []string
+	for _, word := range s.dict.Sorted() {
+		if !s.not[word] {
+			words = append(words, word)
+		}
+	}
+	fits := make([][]bool, len(s.rows))
+	for r, row := range s.rows {
+		fits[r] = make([]bool, len(words))
+		for i, word := range words {
+			fits[r][i] = s.fit(row, word)
+		}
+	}
+	return words, fits
+}
+
+// fit returns true if word could be the solution given row, allowing for up to
+// s.lies wrong marks in a response that isn't a win.
+func (s *Solver) fit(row wordler.Row, word string) bool {
+	score := wordler.Score(row.Guess, word)
+	win := strings.Repeat(string(wordler.CORRECT), len([]rune(score)))
+	if s.lies == 0 || row.Response == win {
+		return score == row.Response
+	}
+	return score != win && wordler.Mismatches(score, row.Response) <= s.lies
+}
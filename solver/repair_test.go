@@ -0,0 +1,66 @@
+package solver
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestCorrections(t *testing.T) {
+	s := From([]string{"foo", "bar", "bam", "bay", "zap", "zoo"})
+	if s.Conflict() != nil || s.Corrections() != nil {
+		t.Errorf("want no conflict or corrections, got %v and %v", s.Conflict(), s.Corrections())
+	}
+
+	// The solution is "bar", but zoo's response should have been "___".
+	if err := s.React("bam", "++_"); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.React("zoo", "+__"); err != nil {
+		t.Fatal(err)
+	}
+	if s.Remaining() != 0 {
+		t.Fatalf("want no words, got %v", s.Candidates())
+	}
+	if want, got := []int{1, 2}, s.Conflict(); !reflect.DeepEqual(want, got) {
+		t.Errorf("want rows %v in conflict, got %v", want, got)
+	}
+
+	want := []string{
+		"if row 2 position 1 was gray instead of green, 2 words remain",
+		"if row 1 position 1 was gray instead of green, 1 word remains",
+	}
+	var got []string
+	for _, c := range s.Corrections() {
+		got = append(got, c.String())
+	}
+	if !reflect.DeepEqual(want, got) {
+		t.Errorf("want %q, got %q", want, got)
+	}
+
+	s.NotInWordle("bay")
+	if err := s.Correct(2, "___"); err != nil {
+		t.Fatal(err)
+	}
+	if want, got := []string{"bar"}, s.Candidates(); !reflect.DeepEqual(want, got) {
+		t.Errorf("want %v, got %v", want, got)
+	}
+	if want, got := "___", s.Rows()[1].Response; want != got {
+		t.Errorf("want %q, got %q", want, got)
+	}
+	if err := s.Correct(3, "___"); err == nil {
+		t.Error("want error for a row that doesn't exist")
+	}
+}
+
+func TestConflictMinimal(t *testing.T) {
+	s := From([]string{"foo", "bar", "bam", "bay", "zap", "zoo"})
+	// Row 3 says there's no 'b', but row 2 says there's one; row 1 is fine.
+	for _, row := range [][2]string{{"zoo", "___"}, {"bam", "++_"}, {"bar", "___"}} {
+		if err := s.React(row[0], row[1]); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if want, got := []int{2, 3}, s.Conflict(); !reflect.DeepEqual(want, got) {
+		t.Errorf("want rows %v in conflict, got %v", want, got)
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"regexp"
 	"strings"
+	"unicode/utf8"
 
 	"wordler"
 	"wordler/wordlist"
@@ -11,113 +12,154 @@ import (
 
 var verbose = false
 
+// Strategy names the way Guess chooses guesses. It changes whenever Guess
+// would choose differently, so that results saved from simulating games can
+// tell whether they're still good.
+const Strategy = "letter-frequency 1"
+
 // Solver is a wordle guesser.
 type Solver struct {
-	have map[byte]bool      // letters that we know we have
-	s    *wordlist.WordList // words that are valid solutions
-	g    *wordlist.WordList // words that are valid guesses
+	known   *Constraint        // what we know about the solution
+	s       *wordlist.WordList // words that are valid solutions
+	g       *wordlist.WordList // words that are valid guesses
+	dict    *wordlist.WordList // words we started with
+	all     *wordlist.WordList // guesses we started with, if not dict
+	rows    []wordler.Row      // guesses and responses, in order
+	not     map[string]bool    // words reported as not in wordle
+	refused map[string]bool    // guesses refused, though they may be the solution
+	lies    int                // wrong marks allowed in each response
+	root    *Tree              // decision tree to follow, if any
+	tree    *Tree              // where we are in root; nil once we've left it
+	book    Book               // guesses to make before computing any
 }
 
-// From returns a new Solver created from the given list of words.
-func From(dictionary []string) *Solver {
+// From returns a new Solver created from the given list of words, limited by
+// the given options. A wordlist.FrequencyOption makes the Solver treat more
+// frequent words as likelier solutions.
+func From(dictionary []string, options ...wordlist.Option) *Solver {
+	w := wordlist.New(dictionary, options...)
 	return &Solver{
-		have: make(map[byte]bool, 26),
-		s:    wordlist.New(dictionary),
-		g:    wordlist.New(dictionary),
+		s:    w,
+		g:    w.Clone(),
+		dict: w.Clone(),
 	}
 }
 
 // New returns a new Solver populated with the default Dictionary.
 func New(options ...wordlist.Option) (*Solver, error) {
-	var (
-		s   *Solver
-		w   *wordlist.WordList
-		err error
-	)
+	return Load(nil, options...)
+}
 
-	if w, err = wordlist.NewDictionary(options...); err == nil {
-		s = &Solver{
-			have: make(map[byte]bool, 26),
-			s:    w,
-			g:    w.Clone(),
-		}
+// Load returns a new Solver populated with the dictionary loaded by l, or by
+// wordlist.Loader if l is nil.
+func Load(l wordlist.DictionaryLoader, options ...wordlist.Option) (*Solver, error) {
+	w, err := wordlist.LoadDictionary(l, options...)
+	if err != nil {
+		return nil, err
 	}
+	return &Solver{
+		s:    w,
+		g:    w.Clone(),
+		dict: w.Clone(),
+	}, nil
+}
 
-	return s, err
+// LoadWithGuesses returns a new Solver whose possible solutions are loaded by
+// answers and whose valid guesses are loaded by guesses.
+func LoadWithGuesses(answers, guesses wordlist.DictionaryLoader, options ...wordlist.Option) (*Solver, error) {
+	s, err := Load(answers, options...)
+	if err != nil {
+		return nil, err
+	}
+	if s.all, err = wordlist.LoadDictionary(guesses, options...); err != nil {
+		return nil, err
+	}
+	s.g = s.all.Clone()
+	return s, nil
 }
 
-// Guess provides a guess from remaining words
+// ForLength returns a new Solver for words of the given length: the Wordle
+// dictionary for 5 letters (or 0), and otherwise the word lists bundled with
+// package wordlist. It returns wordlist.NoBundledWordsErr for other lengths.
+func ForLength(length int, options ...wordlist.Option) (*Solver, error) {
+	if length == wordler.DEFAULT_WORD_LENGTH || length == 0 {
+		return From(wordler.Dictionary, options...), nil
+	}
+	answers, err := wordlist.NewAnswerLoader(length)
+	if err != nil {
+		return nil, err
+	}
+	guesses, err := wordlist.NewGuessLoader(length)
+	if err != nil {
+		return nil, err
+	}
+	return LoadWithGuesses(answers, guesses, options...)
+}
+
+// Guess provides a guess from the opening book, or from the decision tree
+// being followed, or else from remaining words.
 func (s *Solver) Guess() string {
+	if guess, ok := s.fromBook(); ok {
+		return guess
+	}
+	if s.tree != nil {
+		return s.tree.Guess
+	}
 	return s.s.OptimalGuess()
 }
 
 // React "reacts" to the scored guess by filtering out excluded words from our
-// WordList.
+// WordList. The response may use any notation accepted by
+// wordler.ParseResponse.
 func (s *Solver) React(guess, response string) error {
-	pattern := fmt.Sprintf("^[%s]{%d}$", []byte{wordler.CORRECT, wordler.ELSEWHERE, wordler.NIL}, len(guess))
-	if r := regexp.MustCompile(pattern); !r.MatchString(response) {
-		return fmt.Errorf("invalid response: response must match %#v", pattern)
-	}
-	if s.have == nil {
-		s.have = make(map[byte]bool)
-	}
-
-	matches := 0
-	keepOnly := make([]string, len(guess)) // letters in required positions
-
-	// Need to process response signals in this order; see test case for
-	// combination of guess "carer" for word "foyer" in solver_test to
-	// understand why.
-	for _, reaction := range []rune{wordler.CORRECT, wordler.ELSEWHERE, wordler.NIL} {
-		for i, r := range response {
-			if r != reaction {
-				continue
-			}
-			c := guess[i]
-
-			switch r {
-			case wordler.CORRECT:
-				matches++
-				// s.s will be filtered by keepOnly at end of loop.
-				// s.g is filtered here because keepOnly is more strict than
-				// hard mode.
-				keepOnly[i] = string(c)
-				s.have[c] = true
-				s.g.KeepOnly(regexp.MustCompile("^" + strings.Repeat(".", i) + string(c)))
-
-			case wordler.ELSEWHERE:
-				// s.s will be filtered by keepOnly at end of loop; s.g won't.
-				keepOnly[i] = "[^" + string(c) + "]"
-				r := regexp.MustCompile(string(c))
-				s.s.KeepOnly(r) // solution must contain c
-				s.g.KeepOnly(r) // future guesses must contain c
-				s.have[c] = true
-
-			case wordler.NIL:
-				keepOnly[i] = "[^" + string(c) + "]"
-				if !s.have[c] {
-					s.s.Delete(regexp.MustCompile(string(c)))
-					// No update to s.g -- can still use this letter in guesses.
-				}
-			}
-		}
-	}
-
-	debug("found %d matches", matches)
-	if matches == len(guess) {
+	length := utf8.RuneCountInString(guess)
+	response, err := wordler.ParseResponse(response, length)
+	if err != nil {
+		return err
+	}
+	s.follow(guess, response)
+	if s.lies > 0 {
+		return s.reactToLies(guess, response)
+	}
+	row, err := NewConstraint(guess, response)
+	if err != nil {
+		return err
+	}
+	if s.known == nil {
+		s.known = &Constraint{}
+	}
+	if err := s.known.Merge(row); err != nil {
+		return err
+	}
+	s.rows = append(s.rows, wordler.Row{Guess: guess, Response: response})
+	debug("known: %v", s.known)
+
+	if response == strings.Repeat(string(wordler.CORRECT), length) {
 		// complete match!
 		s.s = wordlist.New([]string{guess})
 		s.g = s.s.Clone()
-	} else {
-		p := "^" + strings.Join(keepOnly, "") + "$"
-		debug("keepOnly: '%v'", p)
-		s.s.KeepOnly(regexp.MustCompile(p))
-		// always eliminate guess itself
-		s.g.Delete(regexp.MustCompile(guess))
+		return nil
 	}
+
+	// Filtering by this row alone is enough: s.s already satisfies previous
+	// rows.
+	row.Apply(s.s)
+	// s.g only has to follow hard mode, which is less strict.
+	row.Hard().Apply(s.g)
+	// always eliminate guess itself
+	s.g.KeepOnlyFunc(func(word string) bool { return word != guess })
 	return nil
 }
 
+// Known returns what the solver knows about the solution from the responses it
+// has reacted to.
+func (s *Solver) Known() *Constraint {
+	if s == nil {
+		return nil
+	}
+	return s.known.Clone()
+}
+
 // Remaining returns the number of possible solutions remaining.
 func (s *Solver) Remaining() int {
 	if s == nil || s.s == nil {
@@ -126,15 +168,92 @@ func (s *Solver) Remaining() int {
 	return s.s.Length()
 }
 
+// Candidates returns the possible solutions in alphabetical order.
+func (s *Solver) Candidates() []string {
+	if s == nil {
+		return nil
+	}
+	return s.s.Sorted()
+}
+
+// Weights returns the letter-frequency score of each possible solution; see
+// wordlist.WordList.Weights.
+func (s *Solver) Weights() map[string]int {
+	if s == nil {
+		return map[string]int{}
+	}
+	return s.s.Weights()
+}
+
+// Frequencies returns the frequency of each possible solution, or nil if the
+// Solver wasn't given any with a wordlist.FrequencyOption.
+func (s *Solver) Frequencies() map[string]float64 {
+	if s == nil {
+		return nil
+	}
+	return s.s.Frequencies()
+}
+
+// Buckets partitions the possible solutions by the response each would give
+// to guess, returning how many solutions give each response.
+func (s *Solver) Buckets(guess string) map[string]int {
+	if s == nil {
+		return map[string]int{}
+	}
+	return s.s.Buckets(guess)
+}
+
+// ExpectedRemaining returns the number of possible solutions expected to
+// remain after guess is scored, weighing solutions by their frequency if the
+// Solver has any; see wordlist.WordList.ExpectedRemaining.
+func (s *Solver) ExpectedRemaining(guess string) float64 {
+	if s == nil {
+		return 0
+	}
+	return s.s.ExpectedRemaining(guess)
+}
+
 // NotInWordle is used to report that the word is not found in the wordle
 // dictionary; the word is removed from our list of remaining entries.
 func (s *Solver) NotInWordle(not string) {
 	if s == nil || s.s == nil {
 		return
 	}
-	r := regexp.MustCompile("^" + not + "$")
+	r := regexp.MustCompile("^" + regexp.QuoteMeta(not) + "$")
 	s.s.Delete(r)
 	s.g.Delete(r)
+	if s.tree != nil && s.tree.Guess == not {
+		s.tree = nil
+	}
+	if s.not == nil {
+		s.not = make(map[string]bool)
+	}
+	s.not[not] = true
+}
+
+// Refused reports that word was refused as a guess, as a puzzle with stricter
+// hard rules may, although it may still be the solution. Unlike NotInWordle,
+// the word stays a possible solution; the Solver only stops taking it from its
+// opening book or decision tree.
+func (s *Solver) Refused(word string) {
+	if s == nil {
+		return
+	}
+	if s.tree != nil && s.tree.Guess == word {
+		s.tree = nil
+	}
+	if s.refused == nil {
+		s.refused = make(map[string]bool)
+	}
+	s.refused[word] = true
+}
+
+// Rows returns the guesses and responses the solver has reacted to, in order.
+func (s *Solver) Rows() []wordler.Row {
+	if s == nil {
+		return nil
+	}
+	return append([]wordler.Row{}, s.rows...)
 }
 
 // debug prints debug logs
@@ -1,7 +1,10 @@
 package solver
 
 import (
+	"errors"
+	"reflect"
 	"regexp"
+	"strings"
 	"testing"
 
 	"wordler"
@@ -42,6 +45,70 @@ func TestNew(t *testing.T) {
 	}
 }
 
+func TestLoad(t *testing.T) {
+	l := wordlist.NewReaderLoader(strings.NewReader("smile\nsmirk\nsmelt\n"))
+	s, err := Load(l, wordlist.KeepOnlyOption{Exp: regexp.MustCompile("^smi")})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, got := []string{"smile", "smirk"}, s.Candidates(); !reflect.DeepEqual(want, got) {
+		t.Errorf("want %v, got %v", want, got)
+	}
+
+	if s, err := Load(wordlist.NewFileLoader("/no/such/dictionary")); err == nil {
+		t.Errorf("want error, got %d words", s.Remaining())
+	}
+}
+
+func TestLoadWithGuesses(t *testing.T) {
+	answers := wordlist.NewReaderLoader(strings.NewReader("smile\nsmirk\n"))
+	guesses := wordlist.NewReaderLoader(strings.NewReader("smile\nsmirk\nsmelt\n"))
+	s, err := LoadWithGuesses(answers, guesses)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, got := []string{"smile", "smirk"}, s.Candidates(); !reflect.DeepEqual(want, got) {
+		t.Errorf("want %v, got %v", want, got)
+	}
+	if !s.g.Contains("smelt") {
+		t.Errorf("want 'smelt' in guesses %#v", s.g)
+	}
+}
+
+func TestForLength(t *testing.T) {
+	for _, length := range []int{4, 5, 6, 7} {
+		s, err := ForLength(length)
+		if err != nil {
+			t.Fatalf("%d letters: %v", length, err)
+		}
+		if s.Remaining() == 0 {
+			t.Fatalf("%d letters: no words", length)
+		}
+		if guess := s.Guess(); len(guess) != length {
+			t.Errorf("%d letters: got guess '%s'", length, guess)
+		}
+	}
+
+	// Correcting a response keeps the bundled guesses.
+	s, err := ForLength(6)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.React("zinnia", "______"); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.Correct(1, "_____*"); err != nil {
+		t.Fatal(err)
+	}
+	if s.g.Length() <= s.dict.Length() {
+		t.Errorf("want more guesses than the %d answers, got %d", s.dict.Length(), s.g.Length())
+	}
+
+	if _, err := ForLength(9); !errors.Is(err, wordlist.NoBundledWordsErr) {
+		t.Errorf("want %v, got %v", wordlist.NoBundledWordsErr, err)
+	}
+}
+
 func TestGuess(t *testing.T) {
 	testList := []string{"foo", "bar", "bam", "zap", "zbz"}
 	guesser := From(testList)
@@ -134,10 +201,27 @@ func TestReact(t *testing.T) {
 		})
 	}
 
+	// Color notation is equivalent to the internal notation.
+	for _, c := range cases {
+		response := strings.NewReplacer(string(wordler.CORRECT), "g", string(wordler.ELSEWHERE), "y", string(wordler.NIL), "b").Replace(c.response)
+		t.Run(c.guess+"_"+response, func(t *testing.T) {
+			guesser := From(testList)
+			if err := guesser.React(c.guess, response); err != nil {
+				t.Errorf("got error %v", err)
+			}
+			if want, got := c.solutions, guesser.s; !want.Equals(got) {
+				t.Errorf("want %#v != got %#v", want, got)
+			}
+			if want, got := c.guesses, guesser.g; !want.Equals(got) {
+				t.Errorf("want %#v != got %#v", want, got)
+			}
+		})
+	}
+
 	// guess and response are expected to be same length.
 	// test response validation
 	s := &Solver{}
-	for _, r := range []string{"", "+", "__________", "++ _", "_*+ ", " **+"} {
+	for _, r := range []string{"", "+", "__________", "++ _", "_*+ ", " **+", "gy_b", "21_0"} {
 		t.Run(r, func(t *testing.T) {
 			if err := s.React("this", r); err == nil {
 				t.Error("want error, got nil")
@@ -257,6 +341,28 @@ func TestEliminateGuess(t *testing.T) {
 	}
 }
 
+func TestMultiByte(t *testing.T) {
+	s := From([]string{"слово", "слива", "плита", "сдача"})
+	if err := s.React("слива", "ggxgx"); err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	if want, got := []string{"слово"}, s.Candidates(); !reflect.DeepEqual(want, got) {
+		t.Errorf("want %v, got %v", want, got)
+	}
+
+	s = From([]string{"grüße", "größe", "grube"})
+	if err := s.React("grüße", "ggxgg"); err != nil {
+		t.Fatalf("Error: %v", err)
+	}
+	if want, got := []string{"größe"}, s.Candidates(); !reflect.DeepEqual(want, got) {
+		t.Errorf("want %v, got %v", want, got)
+	}
+	s.NotInWordle("größe")
+	if s.Remaining() != 0 {
+		t.Errorf("want no words, got %v", s.Candidates())
+	}
+}
+
 func TestNotInWordle(t *testing.T) {
 	l := []string{"a", "b", "c"}
 	s := &Solver{s: wordlist.New(l), g: wordlist.New(l)}
@@ -279,3 +385,52 @@ func TestNotInWordle(t *testing.T) {
 		t.Errorf("guesses: want %#v; got %#v", want, s.g)
 	}
 }
+
+func TestCandidates(t *testing.T) {
+	s := From([]string{"foo", "bar", "bam", "zap"})
+	if err := s.React("bar", string([]byte{wordler.CORRECT, wordler.CORRECT, wordler.NIL})); err != nil {
+		t.Fatal(err)
+	}
+	if want, got := []string{"bam"}, s.Candidates(); !reflect.DeepEqual(want, got) {
+		t.Errorf("want %v, got %v", want, got)
+	}
+
+	s = From([]string{"foo", "bar", "bam", "zap"})
+	if want, got := []string{"bam", "bar", "foo", "zap"}, s.Candidates(); !reflect.DeepEqual(want, got) {
+		t.Errorf("want %v, got %v", want, got)
+	}
+
+	s = nil
+	if got := s.Candidates(); got != nil {
+		t.Errorf("want nil, got %v", got)
+	}
+}
+
+func TestLetterCounts(t *testing.T) {
+	// Two marked a's mean the solution has at least two a's.
+	s := From([]string{"aab", "cab", "bac"})
+	if err := s.React("baa", string([]byte{wordler.ELSEWHERE, wordler.CORRECT, wordler.ELSEWHERE})); err != nil {
+		t.Fatal(err)
+	}
+	if want := wordlist.New([]string{"aab"}); !want.Equals(s.s) {
+		t.Errorf("want %#v; got %#v", want, s.s)
+	}
+	if want, got := `pattern ".a."; not b@1, a@3; at least 2 a, 1 b`, s.Known().String(); want != got {
+		t.Errorf("want %v, got %v", want, got)
+	}
+}
+
+func TestGrayDuplicates(t *testing.T) {
+	// "eerie" for "crane" scores one 'e' and marks the others gray, so the
+	// solution has exactly one 'e': "there" is out.
+	s := From([]string{"crane", "there", "brake", "eerie"})
+	if err := s.React("eerie", wordler.Score("eerie", "crane")); err != nil {
+		t.Fatal(err)
+	}
+	if want := wordlist.New([]string{"brake", "crane"}); !want.Equals(s.s) {
+		t.Errorf("want %#v; got %#v", want, s.s)
+	}
+	if want, got := `pattern "....e"; not e@1, e@2, r@3, i@4; at least 1 e, 1 r; at most 1 e, 0 i`, s.Known().String(); want != got {
+		t.Errorf("want %v, got %v", want, got)
+	}
+}
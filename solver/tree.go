@@ -0,0 +1,200 @@
+package solver
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"wordler"
+)
+
+var (
+	LiesErr       = errors.New("cannot build a decision tree while tolerating lies")
+	NoProgressErr = errors.New("guess does not narrow the possible solutions")
+)
+
+// Tree is a decision tree: the guess to make, and for each response to it
+// but a win, the tree to follow next. A Tree records every guess a strategy
+// makes for every possible solution, so it can be audited, and played back
+// without computing anything.
+type Tree struct {
+	Guess string           `json:"guess"`
+	Words int              `json:"words"`          // possible solutions before Guess
+	Next  map[string]*Tree `json:"next,omitempty"` // by response to Guess
+}
+
+// Tree builds the decision tree the Solver follows from its current state,
+// guessing as Guess does and reacting to every response a possible solution
+// would give.
+func (s *Solver) Tree() (*Tree, error) {
+	if s == nil || s.Remaining() == 0 {
+		return nil, nil
+	}
+	if s.lies > 0 {
+		return nil, LiesErr
+	}
+	guess := s.Guess()
+	t := &Tree{Guess: guess, Words: s.Remaining()}
+	win := strings.Repeat(string(wordler.CORRECT), len([]rune(guess)))
+	for response, n := range s.Buckets(guess) {
+		if response == win {
+			continue
+		}
+		if n == t.Words {
+			return nil, fmt.Errorf("'%s' %w", guess, NoProgressErr)
+		}
+		next := s.clone()
+		if err := next.React(guess, response); err != nil {
+			return nil, err
+		}
+		child, err := next.Tree()
+		if err != nil {
+			return nil, err
+		}
+		if t.Next == nil {
+			t.Next = make(map[string]*Tree)
+		}
+		t.Next[response] = child
+	}
+	return t, nil
+}
+
+// Follow makes the Solver guess by looking each guess up in t rather than
+// computing it. React moves down t; once a guess or response leaves t, the
+// Solver computes its guesses again.
+func (s *Solver) Follow(t *Tree) {
+	if s != nil {
+		s.root, s.tree = t, t
+	}
+}
+
+// follow moves down the tree being followed, past guess and its response.
+func (s *Solver) follow(guess, response string) {
+	if s.tree == nil {
+		return
+	}
+	if s.tree.Guess != guess {
+		s.tree = nil
+		return
+	}
+	s.tree = s.tree.Next[response]
+}
+
+// clone returns a copy of s that reacts independently of it.
+func (s *Solver) clone() *Solver {
+	c := *s
+	c.s, c.g = s.s.Clone(), s.g.Clone()
+	if s.known != nil {
+		c.known = s.known.Clone()
+	}
+	c.rows = append([]wordler.Row{}, s.rows...)
+	return &c
+}
+
+// Guesses returns the number of guesses t takes to solve each word it can.
+func (t *Tree) Guesses() map[string]int {
+	guesses := make(map[string]int)
+	var walk func(t *Tree, depth int)
+	walk = func(t *Tree, depth int) {
+		if t == nil {
+			return
+		}
+		if _, ok := guesses[t.Guess]; !ok && t.solves() {
+			guesses[t.Guess] = depth
+		}
+		for _, next := range t.Next {
+			walk(next, depth+1)
+		}
+	}
+	walk(t, 1)
+	return guesses
+}
+
+// solves returns true if Guess may be the solution when it's made: that is,
+// unless every possible solution gives some other response.
+func (t *Tree) solves() bool {
+	n := 0
+	for _, next := range t.Next {
+		n += next.Words
+	}
+	return n < t.Words
+}
+
+// Stats returns the average and the largest number of guesses t takes to
+// solve a word.
+func (t *Tree) Stats() (average float64, worst int) {
+	guesses := t.Guesses()
+	if len(guesses) == 0 {
+		return 0, 0
+	}
+	total := 0
+	for _, n := range guesses {
+		total += n
+		if n > worst {
+			worst = n
+		}
+	}
+	return float64(total) / float64(len(guesses)), worst
+}
+
+// WriteJSON writes t to w as indented JSON.
+func (t *Tree) WriteJSON(w io.Writer) error {
+	e := json.NewEncoder(w)
+	e.SetIndent("", " ")
+	return e.Encode(t)
+}
+
+// ReadTree reads a Tree written by WriteJSON.
+func ReadTree(r io.Reader) (*Tree, error) {
+	t := &Tree{}
+	if err := json.NewDecoder(r).Decode(t); err != nil {
+		return nil, err
+	}
+	if t.Guess == "" {
+		return nil, errors.New("invalid tree: no guess")
+	}
+	return t, nil
+}
+
+// WriteDot writes t to w as a Graphviz DOT graph: a box for each guess,
+// showing how many words were possible before it, and an arrow labeled with
+// each response.
+func (t *Tree) WriteDot(w io.Writer) error {
+	if _, err := fmt.Fprintln(w, "digraph wordler {\n\tnode [shape=box];"); err != nil {
+		return err
+	}
+	id := 0
+	var walk func(t *Tree) (int, error)
+	walk = func(t *Tree) (int, error) {
+		n := id
+		id++
+		if _, err := fmt.Fprintf(w, "\tn%d [label=\"%s\\n%d\"];\n", n, t.Guess, t.Words); err != nil {
+			return n, err
+		}
+		responses := make([]string, 0, len(t.Next))
+		for response := range t.Next {
+			responses = append(responses, response)
+		}
+		sort.Strings(responses)
+		for _, response := range responses {
+			child, err := walk(t.Next[response])
+			if err != nil {
+				return n, err
+			}
+			if _, err := fmt.Fprintf(w, "\tn%d -> n%d [label=\"%s\"];\n", n, child, response); err != nil {
+				return n, err
+			}
+		}
+		return n, nil
+	}
+	if t != nil {
+		if _, err := walk(t); err != nil {
+			return err
+		}
+	}
+	_, err := fmt.Fprintln(w, "}")
+	return err
+}
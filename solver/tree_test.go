@@ -0,0 +1,156 @@
+package solver
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"wordler"
+)
+
+var treeWords = []string{"foo", "bar", "bam", "bay", "zap", "zoo"}
+
+func TestTree(t *testing.T) {
+	tree, err := From(treeWords).Tree()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, got := len(treeWords), tree.Words; want != got {
+		t.Errorf("want %d words, got %d", want, got)
+	}
+	if want, got := From(treeWords).Guess(), tree.Guess; want != got {
+		t.Errorf("want first guess %q, got %q", want, got)
+	}
+
+	guesses := tree.Guesses()
+	if len(guesses) != len(treeWords) {
+		t.Errorf("want every word solved, got %v", guesses)
+	}
+	total, worst := 0, 0
+	for _, word := range treeWords {
+		// Playing by the tree takes as many guesses as it says, and as
+		// many as computing each guess does.
+		followed := From(treeWords)
+		followed.Follow(tree)
+		for _, s := range []*Solver{followed, From(treeWords)} {
+			n := 0
+			for {
+				n++
+				guess := s.Guess()
+				response := wordler.Score(guess, word)
+				if guess == word {
+					break
+				}
+				if err := s.React(guess, response); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if want, got := guesses[word], n; want != got {
+				t.Errorf("'%s': want %d guesses, got %d", word, want, got)
+			}
+		}
+		total += guesses[word]
+		if guesses[word] > worst {
+			worst = guesses[word]
+		}
+	}
+	average, w := tree.Stats()
+	if want := float64(total) / float64(len(treeWords)); want != average || worst != w {
+		t.Errorf("want average %v and worst %d, got %v and %d", want, worst, average, w)
+	}
+}
+
+func TestTreeLies(t *testing.T) {
+	s := From(treeWords)
+	s.Tolerate(1)
+	if _, err := s.Tree(); !errors.Is(err, LiesErr) {
+		t.Errorf("want %v, got %v", LiesErr, err)
+	}
+}
+
+func TestTreeJSON(t *testing.T) {
+	tree, err := From(treeWords).Tree()
+	if err != nil {
+		t.Fatal(err)
+	}
+	var b bytes.Buffer
+	if err := tree.WriteJSON(&b); err != nil {
+		t.Fatal(err)
+	}
+	got, err := ReadTree(&b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(tree, got) {
+		t.Errorf("want %+v, got %+v", tree, got)
+	}
+
+	if _, err := ReadTree(strings.NewReader("{}")); err == nil {
+		t.Error("want error for a tree without a guess")
+	}
+}
+
+func TestTreeDot(t *testing.T) {
+	tree := &Tree{Guess: "bam", Words: 3, Next: map[string]*Tree{
+		"++_": {Guess: "bar", Words: 1},
+		"___": {Guess: "foo", Words: 1},
+	}}
+	var b bytes.Buffer
+	if err := tree.WriteDot(&b); err != nil {
+		t.Fatal(err)
+	}
+	want := `digraph wordler {
+	node [shape=box];
+	n0 [label="bam\n3"];
+	n1 [label="bar\n1"];
+	n0 -> n1 [label="++_"];
+	n2 [label="foo\n1"];
+	n0 -> n2 [label="___"];
+}
+`
+	if got := b.String(); want != got {
+		t.Errorf("want %q, got %q", want, got)
+	}
+}
+
+func TestFollow(t *testing.T) {
+	// A tree that guesses differently than the Solver would.
+	tree := &Tree{Guess: "zoo", Words: 6, Next: map[string]*Tree{
+		"___": {Guess: "bay", Words: 3, Next: map[string]*Tree{
+			"++_": {Guess: "bam", Words: 2},
+		}},
+	}}
+	s := From(treeWords)
+	s.Follow(tree)
+	if want, got := "zoo", s.Guess(); want != got {
+		t.Fatalf("want %q, got %q", want, got)
+	}
+	if err := s.React("zoo", "___"); err != nil {
+		t.Fatal(err)
+	}
+	if want, got := "bay", s.Guess(); want != got {
+		t.Fatalf("want %q, got %q", want, got)
+	}
+	if err := s.React("bay", "++_"); err != nil {
+		t.Fatal(err)
+	}
+	if want, got := "bam", s.Guess(); want != got {
+		t.Fatalf("want %q, got %q", want, got)
+	}
+
+	// Leaving the tree goes back to computing guesses.
+	s.NotInWordle("bam")
+	if want, got := "bar", s.Guess(); want != got {
+		t.Errorf("want %q, got %q", want, got)
+	}
+
+	// Correcting a response replays the tree from the start.
+	if err := s.Correct(2, "_+_"); err != nil {
+		t.Fatal(err)
+	}
+	if s.tree != nil {
+		t.Errorf("want to have left the tree, at %+v", s.tree)
+	}
+}
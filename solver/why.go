@@ -0,0 +1,113 @@
+package solver
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"wordler"
+)
+
+var (
+	NotEliminatedErr = errors.New("not eliminated")
+	UnknownWordErr   = errors.New("not in the solver's dictionary")
+)
+
+// Elimination explains why a word is not a possible solution.
+type Elimination struct {
+	Word   string      // the eliminated word
+	Row    int         // 1-based index of the row that ruled out Word; 0 if no row did
+	Guess  wordler.Row // the row that ruled out Word
+	Reason string      // the specific constraint Word breaks
+}
+
+// String fulfills the fmt.Stringer interface.
+func (e *Elimination) String() string {
+	return fmt.Sprintf("'%s' %s", e.Word, e.Reason)
+}
+
+// Why explains why word is no longer a possible solution: it returns the first
+// guess whose response rules out word and the constraint that word breaks.
+// Why returns NotEliminatedErr if word is still possible and UnknownWordErr if
+// the solver never considered word.
+func (s *Solver) Why(word string) (*Elimination, error) {
+	if s == nil {
+		return nil, fmt.Errorf("'%s' %w", word, UnknownWordErr)
+	}
+	if s.s.Contains(word) {
+		return nil, fmt.Errorf("'%s' %w", word, NotEliminatedErr)
+	}
+	if s.not[word] {
+		return &Elimination{Word: word, Reason: "was reported as not in wordle's dictionary"}, nil
+	}
+	if s.dict != nil && !s.dict.Contains(word) {
+		return nil, fmt.Errorf("'%s' %w", word, UnknownWordErr)
+	}
+
+	for i, row := range s.rows {
+		var reason string
+		if s.lies > 0 {
+			reason = explainLie(row, i+1, word, s.lies)
+		} else {
+			reason = explain(row, i+1, word)
+		}
+		if reason != "" {
+			return &Elimination{Word: word, Row: i + 1, Guess: row, Reason: reason}, nil
+		}
+	}
+	return nil, fmt.Errorf("'%s' %w by any guess", word, NotEliminatedErr)
+}
+
+// explain returns the constraint from row, the n-th guess, that word breaks, or
+// the empty string if word is consistent with row.
+func explain(row wordler.Row, n int, word string) string {
+	g, r, w := []rune(row.Guess), []rune(row.Response), []rune(word)
+	if len(w) != len(g) {
+		return fmt.Sprintf("has %d letters but guess %d ('%s') has %d", len(w), n, row.Guess, len(g))
+	}
+
+	// Letters in the right place come first; they're the easiest to see.
+	for i, c := range g {
+		if r[i] == wordler.CORRECT && w[i] != c {
+			return fmt.Sprintf("does not have '%c' in position %d, which was green in guess %d ('%s')", c, i+1, n, row.Guess)
+		}
+	}
+
+	// Next, letters known not to be in a given position.
+	for i, c := range g {
+		if w[i] != c {
+			continue
+		}
+		switch r[i] {
+		case wordler.ELSEWHERE:
+			return fmt.Sprintf("has '%c' in position %d, which was yellow there in guess %d ('%s')", c, i+1, n, row.Guess)
+		case wordler.NIL:
+			return fmt.Sprintf("has '%c' in position %d, which was gray there in guess %d ('%s')", c, i+1, n, row.Guess)
+		}
+	}
+
+	// Finally, letter counts.
+	found := make(map[rune]int) // how many of each letter the solution has, at least
+	gray := make(map[rune]bool) // letters whose count is exactly found[c]
+	for i, c := range g {
+		if r[i] == wordler.NIL {
+			gray[c] = true
+		} else {
+			found[c]++
+		}
+	}
+	for _, c := range g {
+		have := strings.Count(word, string(c))
+		switch {
+		case have < found[c] && found[c] == 1:
+			return fmt.Sprintf("does not contain '%c', which was yellow in guess %d ('%s')", c, n, row.Guess)
+		case have < found[c]:
+			return fmt.Sprintf("contains %d '%c' but guess %d ('%s') showed at least %d", have, c, n, row.Guess, found[c])
+		case gray[c] && have > found[c] && found[c] == 0:
+			return fmt.Sprintf("contains '%c', which was gray in guess %d ('%s')", c, n, row.Guess)
+		case gray[c] && have > found[c]:
+			return fmt.Sprintf("contains %d '%c' but guess %d ('%s') showed exactly %d", have, c, n, row.Guess, found[c])
+		}
+	}
+	return ""
+}
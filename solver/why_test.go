@@ -0,0 +1,80 @@
+package solver
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"wordler"
+)
+
+func TestWhy(t *testing.T) {
+	s := From([]string{"crate", "irate", "brace", "haste", "plate", "sloth", "trace"})
+	for _, guess := range []string{"sloth", "trace"} {
+		if err := s.React(guess, wordler.Score(guess, "crate")); err != nil {
+			t.Fatal(err)
+		}
+	}
+	s.NotInWordle("plate")
+
+	cases := []struct {
+		word   string
+		row    int
+		reason string // substring of the explanation
+	}{
+		{"brace", 1, "does not have 't' in position 4, which was green in guess 1 ('sloth')"},
+		{"haste", 1, "contains 's', which was gray in guess 1 ('sloth')"},
+		{"trace", 1, "does not have 't' in position 4"},
+		{"irate", 2, "does not contain 'c', which was yellow in guess 2 ('trace')"},
+		{"plate", 0, "not in wordle's dictionary"},
+	}
+	for _, c := range cases {
+		t.Run(c.word, func(t *testing.T) {
+			e, err := s.Why(c.word)
+			if err != nil {
+				t.Fatalf("got error %v", err)
+			}
+			if want, got := c.row, e.Row; want != got {
+				t.Errorf("want row %d, got %d (%v)", want, got, e)
+			}
+			if !strings.Contains(e.Reason, c.reason) {
+				t.Errorf("want reason containing %q, got %q", c.reason, e.Reason)
+			}
+		})
+	}
+
+	if _, err := s.Why("crate"); !errors.Is(err, NotEliminatedErr) {
+		t.Errorf("want %v, got %v", NotEliminatedErr, err)
+	}
+	if _, err := s.Why("bogus"); !errors.Is(err, UnknownWordErr) {
+		t.Errorf("want %v, got %v", UnknownWordErr, err)
+	}
+}
+
+func TestExplain(t *testing.T) {
+	cases := []struct {
+		row    wordler.Row
+		word   string
+		reason string // substring of the explanation; empty if consistent
+	}{
+		{wordler.Row{Guess: "eerie", Response: "__*_+"}, "crane", ""},
+		{wordler.Row{Guess: "eerie", Response: "__*_+"}, "there", "contains 2 'e' but guess 1 ('eerie') showed exactly 1"},
+		{wordler.Row{Guess: "abbey", Response: "_*_*_"}, "probe", ""},
+		{wordler.Row{Guess: "abbey", Response: "_*_*_"}, "crime", "does not contain 'b', which was yellow"},
+		{wordler.Row{Guess: "abbey", Response: "_*_*_"}, "bribe", "contains 2 'b' but guess 1 ('abbey') showed exactly 1"},
+		{wordler.Row{Guess: "abbey", Response: "_*_*_"}, "tubes", "has 'b' in position 3, which was gray there"},
+		{wordler.Row{Guess: "abbey", Response: "***__"}, "blast", "contains 1 'b' but guess 1 ('abbey') showed at least 2"},
+		{wordler.Row{Guess: "abbey", Response: "_____"}, "four", "has 4 letters"},
+	}
+	for _, c := range cases {
+		t.Run(c.row.Guess+"_"+c.word, func(t *testing.T) {
+			got := explain(c.row, 1, c.word)
+			if c.reason == "" && got != "" {
+				t.Errorf("want consistent, got %q", got)
+			}
+			if !strings.Contains(got, c.reason) {
+				t.Errorf("want reason containing %q, got %q", c.reason, got)
+			}
+		})
+	}
+}
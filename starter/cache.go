@@ -0,0 +1,97 @@
+package starter
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// Cache holds the ranks already rated against one list of solutions and
+// guesses, by guess, and saves them to a file, so that later runs needn't rate
+// them again.
+type Cache struct {
+	path string
+
+	mu    sync.Mutex
+	Key   string          `json:"key"`   // identifies what the ranks were rated against
+	Ranks map[string]Rank `json:"ranks"` // by guess
+}
+
+// Key returns a key for a Cache identifying the solver strategy simulated
+// games were played with, such as solver.Strategy, and the lists of solutions
+// and of guesses the solver may make, in order.
+func Key(strategy string, solutions, guesses []string) string {
+	h := sha256.New()
+	for _, s := range []string{strategy, strings.Join(solutions, "\n"), strings.Join(guesses, "\n")} {
+		fmt.Fprintf(h, "%d:%s", len(s), s)
+	}
+	return fmt.Sprintf("%x", h.Sum(nil))
+}
+
+// LoadCache loads the Cache saved at path. The Cache is empty if there's no
+// file at path yet, or if its ranks were rated against anything other than
+// what key identifies.
+func LoadCache(path, key string) (*Cache, error) {
+	c := &Cache{path: path, Key: key, Ranks: make(map[string]Rank)}
+	data, err := os.ReadFile(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return c, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	saved := &Cache{}
+	if err := json.Unmarshal(data, saved); err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	if saved.Key == key && saved.Ranks != nil {
+		c.Ranks = saved.Ranks
+	}
+	return c, nil
+}
+
+// Get returns the cached rank of guess, or a Rank with only Guess set.
+func (c *Cache) Get(guess string) Rank {
+	if c == nil {
+		return Rank{Guess: guess}
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if r, ok := c.Ranks[guess]; ok {
+		return r
+	}
+	return Rank{Guess: guess}
+}
+
+// Put adds r to the Cache, unless it hasn't been rated.
+func (c *Cache) Put(r Rank) {
+	if c == nil || !r.rated() {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.Ranks[r.Guess] = r
+}
+
+// Save writes the Cache to its file, creating its directory if need be.
+func (c *Cache) Save() error {
+	if c == nil {
+		return nil
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	data, err := json.Marshal(c)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(c.path), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(c.path, data, 0644)
+}
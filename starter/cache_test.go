@@ -0,0 +1,75 @@
+package starter
+
+import (
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"wordler/wordlist"
+)
+
+func TestCache(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "cache", "starter.json")
+	key := Key("strategy", words, words)
+	c, err := LoadCache(path, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, got := (Rank{Guess: "bam"}), c.Get("bam"); want != got {
+		t.Errorf("want %v, got %v", want, got)
+	}
+
+	rank := Measure(wordlist.New(words), "bam")
+	c.Put(rank)
+	c.Put(Rank{Guess: "zoo"}) // not rated, so not cached
+	if err := c.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	c, err = LoadCache(path, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want, got := map[string]Rank{"bam": rank}, c.Ranks; !reflect.DeepEqual(want, got) {
+		t.Errorf("want %v, got %v", want, got)
+	}
+
+	// Ranks rated against other solutions or guesses, or simulated with
+	// another strategy, aren't reused.
+	for _, other := range []string{
+		Key("strategy", words[1:], words),
+		Key("strategy", words, words[1:]),
+		Key("other strategy", words, words),
+	} {
+		c, err = LoadCache(path, other)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(c.Ranks) != 0 {
+			t.Errorf("want no ranks, got %v", c.Ranks)
+		}
+	}
+
+	var none *Cache
+	none.Put(rank)
+	if want, got := (Rank{Guess: "bam"}), none.Get("bam"); want != got || none.Save() != nil {
+		t.Errorf("want %v from a nil Cache, got %v", want, got)
+	}
+}
+
+func TestRankerCache(t *testing.T) {
+	c, err := LoadCache(filepath.Join(t.TempDir(), "starter.json"), Key("strategy", words, words))
+	if err != nil {
+		t.Fatal(err)
+	}
+	cached := Rank{Guess: "bam", Entropy: 9, Worst: 9, Expected: 9}
+	c.Put(cached)
+	r := &Ranker{Solutions: wordlist.New(words), Cache: c}
+	ranks := r.Measure([]string{"bam", "zoo"})
+	if want, got := cached, ranks[0]; want != got {
+		t.Errorf("want the cached %v, got %v", want, got)
+	}
+	if want, got := Measure(wordlist.New(words), "zoo"), c.Get("zoo"); !near(want, got) {
+		t.Errorf("want %v cached, got %v", want, got)
+	}
+}
@@ -0,0 +1,206 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+
+	"wordler"
+	"wordler/solver"
+	"wordler/starter"
+	"wordler/wordlist"
+)
+
+func main() {
+	dictionary := flag.String("dictionary", "", "load words from this file, one per line (optionally gzip-compressed), in place of Wordle dictionary")
+	length := flag.Int("length", wordler.DEFAULT_WORD_LENGTH, "word length")
+	top := flag.Int("top", 10, "number of guesses to list for each metric")
+	simulate := flag.Int("simulate", 10, "simulate games for this many of the best guesses by each other metric")
+	metric := flag.String("metric", "", fmt.Sprintf("list guesses by this metric only: one of %v", starter.Metrics))
+	workers := flag.Int("workers", 0, "number of guesses to rate at once; 0 means one per CPU")
+	bookFile := flag.String("book", "", "write an opening book with the guess the solver averages the fewest guesses opening with to this file, for solver/main --book")
+	cacheDir := flag.String("cache", defaultCache(), "save ratings in this directory and reuse them; empty to disable")
+	usage := flag.Usage
+	flag.Usage = func() {
+		usage()
+		fmt.Fprintf(flag.CommandLine.Output(), "\nRanks every allowed guess as an opening move. Simulating games is slow, so\n")
+		fmt.Fprintf(flag.CommandLine.Output(), "only the best guesses by the other metrics are simulated.\n")
+	}
+	flag.Parse()
+
+	metrics := starter.Metrics
+	if *metric != "" {
+		metrics = []string{*metric}
+		if err := starter.ValidMetric(*metric); err != nil {
+			fmt.Println("ERROR: ", err)
+			os.Exit(2)
+		}
+	}
+
+	solutions, guesses, err := load(*dictionary, *length)
+	if err != nil {
+		fmt.Println("ERROR: ", err)
+		os.Exit(2)
+	}
+	var cache *starter.Cache
+	if *cacheDir != "" {
+		key := starter.Key(solver.Strategy, solutions.Sorted(), guesses)
+		if cache, err = starter.LoadCache(filepath.Join(*cacheDir, "starter-"+key[:16]+".json"), key); err != nil {
+			fmt.Println("ERROR: ", err)
+			os.Exit(2)
+		}
+	}
+	r := &starter.Ranker{
+		Solutions: solutions,
+		NewSolver: func() (*solver.Solver, error) {
+			if *dictionary != "" {
+				return solver.Load(wordlist.NewFileLoader(*dictionary), keepLength(*length))
+			}
+			return solver.ForLength(*length)
+		},
+		Workers: *workers,
+		Cache:   cache,
+	}
+
+	fmt.Printf("Rating %d guesses against %d possible solutions.\n", len(guesses), solutions.Length())
+	r.Progress = progress("Measuring")
+	ranks := r.Measure(guesses)
+
+	// Simulate the best guesses by each of the other metrics.
+	chosen := make(map[string]bool)
+	var simulated []string
+	for _, m := range starter.Metrics {
+		if m == starter.Average {
+			continue
+		}
+		starter.Sort(ranks, m)
+		for i := 0; i < *simulate && i < len(ranks); i++ {
+			if guess := ranks[i].Guess; !chosen[guess] {
+				chosen[guess] = true
+				simulated = append(simulated, guess)
+			}
+		}
+	}
+	r.Progress = progress("Simulating")
+	best, err := r.Simulate(simulated)
+	if err != nil {
+		fmt.Println("ERROR: ", err)
+		os.Exit(1)
+	}
+	by := make(map[string]starter.Rank, len(best))
+	for _, rank := range best {
+		by[rank.Guess] = rank
+	}
+	for i := range ranks {
+		if rank, ok := by[ranks[i].Guess]; ok {
+			ranks[i] = rank
+		}
+	}
+	if err := cache.Save(); err != nil {
+		fmt.Println("ERROR: ", err)
+		os.Exit(1)
+	}
+
+	for _, m := range metrics {
+		list := ranks
+		if m == starter.Average {
+			// Only the simulated guesses have an average.
+			list = best
+			fmt.Printf("\nBest openers by %s, of the %d guesses simulated:\n", m, len(best))
+		} else {
+			fmt.Printf("\nBest openers by %s:\n", m)
+		}
+		starter.Sort(list, m)
+		for i := 0; i < *top && i < len(list); i++ {
+			fmt.Printf("%4d. %v\n", i+1, list[i])
+		}
+	}
+	starter.Sort(best, starter.Average)
+	if len(best) > 0 {
+		fmt.Printf("\nThe solver averages the fewest guesses opening with '%s'; try:\n", best[0].Guess)
+		fmt.Printf("    go run ./solver/main --length=%d %s\n", *length, best[0].Guess)
+	}
+	if *bookFile != "" && len(best) > 0 {
+		if err := writeBook(*bookFile, solver.Book{"": best[0].Guess}); err != nil {
+			fmt.Println("ERROR: ", err)
+			os.Exit(1)
+		}
+		fmt.Printf("Wrote an opening book to %s; use it with --book=%s.\n", *bookFile, *bookFile)
+	}
+}
+
+// load returns the possible solutions and the allowed guesses: those in the
+// named dictionary file if there is one, or else the Wordle dictionary or the
+// bundled word lists for the given length.
+func load(dictionary string, length int) (*wordlist.WordList, []string, error) {
+	if dictionary != "" {
+		solutions, err := wordlist.NewFileLoader(dictionary).Load(keepLength(length))
+		if err != nil {
+			return nil, nil, err
+		}
+		return solutions, solutions.Sorted(), nil
+	}
+	if length == wordler.DEFAULT_WORD_LENGTH {
+		solutions := wordlist.New(wordler.Dictionary)
+		return solutions, solutions.Sorted(), nil
+	}
+	answers, err := wordlist.NewAnswerLoader(length)
+	if err != nil {
+		return nil, nil, err
+	}
+	solutions, err := answers.Load()
+	if err != nil {
+		return nil, nil, err
+	}
+	l, err := wordlist.NewGuessLoader(length)
+	if err != nil {
+		return nil, nil, err
+	}
+	guesses, err := l.Load()
+	if err != nil {
+		return nil, nil, err
+	}
+	return solutions, guesses.Sorted(), nil
+}
+
+// writeBook writes b to the named file.
+func writeBook(path string, b solver.Book) error {
+	file, err := os.Create(path)
+	if err != nil {
+		return err
+	}
+	if err := b.Write(file); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
+
+// keepLength limits a dictionary to words of the given length.
+func keepLength(length int) wordlist.Option {
+	return wordlist.KeepOnlyOption{Exp: regexp.MustCompile(fmt.Sprintf(`^\p{Ll}{%d}$`, length))}
+}
+
+// defaultCache returns the default directory of the ratings cache, or the
+// empty string if there's no place for it.
+func defaultCache() string {
+	dir, err := os.UserCacheDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(dir, "wordler")
+}
+
+// progress returns a function that reports progress on stderr.
+func progress(what string) func(done, total int) {
+	return func(done, total int) {
+		if done%100 == 0 || done == total {
+			fmt.Fprintf(os.Stderr, "\r%s: %d/%d", what, done, total)
+		}
+		if done == total {
+			fmt.Fprintln(os.Stderr)
+		}
+	}
+}
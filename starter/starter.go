@@ -0,0 +1,220 @@
+// Package starter ranks guesses as the opening move of a game.
+package starter
+
+import (
+	"fmt"
+	"math"
+	"runtime"
+	"sort"
+	"sync"
+
+	"wordler/solver"
+	"wordler/wordlist"
+)
+
+// The metrics guesses can be ranked by.
+const (
+	Entropy  = "entropy"  // information the response gives, in bits; more is better
+	Worst    = "worst"    // words left by the worst response; fewer is better
+	Expected = "expected" // words expected to remain; fewer is better
+	Average  = "average"  // guesses the solver takes on average; fewer is better
+)
+
+// Metrics are the names of the metrics, in the order they're reported.
+var Metrics = []string{Entropy, Worst, Expected, Average}
+
+// ValidMetric returns an error unless metric is one of Metrics.
+func ValidMetric(metric string) error {
+	for _, m := range Metrics {
+		if m == metric {
+			return nil
+		}
+	}
+	return fmt.Errorf("unknown metric %q: want one of %v", metric, Metrics)
+}
+
+// Rank rates a guess as an opening move.
+type Rank struct {
+	Guess      string  `json:"guess"`
+	Entropy    float64 `json:"entropy"`   // expected information from the response, in bits
+	Worst      int     `json:"worst"`     // possible solutions left by the largest bucket
+	Expected   float64 `json:"expected"`  // possible solutions expected to remain
+	Simulated  bool    `json:"simulated"` // whether Average and MaxGuesses were simulated
+	Average    float64 `json:"average"`   // guesses the solver takes on average, opening with Guess
+	MaxGuesses int     `json:"max"`       // the most guesses the solver takes, opening with Guess
+}
+
+// String fulfills the fmt.Stringer interface.
+func (r Rank) String() string {
+	s := fmt.Sprintf("%s  entropy %.3f, worst %d, expected %.1f", r.Guess, r.Entropy, r.Worst, r.Expected)
+	if r.Simulated {
+		s += fmt.Sprintf(", average %.3f guesses (max %d)", r.Average, r.MaxGuesses)
+	}
+	return s
+}
+
+// Measure rates guess as an opening move against solutions, without
+// simulating any games.
+func Measure(solutions *wordlist.WordList, guess string) Rank {
+	r := Rank{Guess: guess, Expected: solutions.ExpectedRemaining(guess)}
+	n := float64(solutions.Length())
+	for _, b := range solutions.Buckets(guess) {
+		p := float64(b) / n
+		r.Entropy -= p * math.Log2(p)
+		if b > r.Worst {
+			r.Worst = b
+		}
+	}
+	return r
+}
+
+// Simulate plays every possible solution of s, opening with guess and then
+// guessing as s does, and returns the average and the largest number of
+// guesses taken. s should be a new Solver.
+func Simulate(s *solver.Solver, guess string) (average float64, max int, err error) {
+	s.Follow(&solver.Tree{Guess: guess})
+	tree, err := s.Tree()
+	if err != nil {
+		return 0, 0, err
+	}
+	if tree == nil {
+		return 0, 0, fmt.Errorf("no possible solutions")
+	}
+	average, max = tree.Stats()
+	return average, max, nil
+}
+
+// Ranker rates many guesses at once, in parallel.
+type Ranker struct {
+	// Solutions are the possible solutions.
+	Solutions *wordlist.WordList
+	// NewSolver returns a new Solver for simulating games.
+	NewSolver func() (*solver.Solver, error)
+	// Workers is how many guesses are rated at once; 0 means one per CPU.
+	Workers int
+	// Progress, if set, is called after each guess is rated.
+	Progress func(done, total int)
+	// Cache, if set, holds ratings already made; new ratings are added to it.
+	Cache *Cache
+}
+
+// Measure rates each guess without simulating, as Measure does, returning
+// the ranks in the order of guesses.
+func (r *Ranker) Measure(guesses []string) []Rank {
+	ranks, _ := r.run(guesses, func(solutions *wordlist.WordList, rank *Rank) error {
+		if !rank.rated() {
+			*rank = Measure(solutions, rank.Guess)
+		}
+		return nil
+	})
+	return ranks
+}
+
+// Simulate rates each guess, simulating games opening with it, and returns
+// the ranks in the order of guesses.
+func (r *Ranker) Simulate(guesses []string) ([]Rank, error) {
+	return r.run(guesses, func(solutions *wordlist.WordList, rank *Rank) error {
+		if rank.Simulated {
+			return nil
+		}
+		if !rank.rated() {
+			*rank = Measure(solutions, rank.Guess)
+		}
+		s, err := r.NewSolver()
+		if err != nil {
+			return err
+		}
+		rank.Average, rank.MaxGuesses, err = Simulate(s, rank.Guess)
+		rank.Simulated = err == nil
+		return err
+	})
+}
+
+// rated returns true if the rank has been measured.
+func (r *Rank) rated() bool {
+	return r.Worst > 0
+}
+
+// run rates each guess with rate, in parallel, starting from its cached rank
+// if there is one.
+func (r *Ranker) run(guesses []string, rate func(*wordlist.WordList, *Rank) error) ([]Rank, error) {
+	ranks := make([]Rank, len(guesses))
+	for i, guess := range guesses {
+		ranks[i] = r.Cache.Get(guess)
+	}
+	workers := r.Workers
+	if workers <= 0 {
+		workers = runtime.NumCPU()
+	}
+
+	var (
+		wg    sync.WaitGroup
+		mu    sync.Mutex // guards done, first and calls to Progress
+		done  int
+		first error
+	)
+	next := make(chan int)
+	for w := 0; w < workers; w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			solutions := r.Solutions.Clone()
+			for i := range next {
+				err := rate(solutions, &ranks[i])
+				mu.Lock()
+				done++
+				if err != nil && first == nil {
+					first = fmt.Errorf("'%s': %w", guesses[i], err)
+				}
+				if r.Progress != nil {
+					r.Progress(done, len(guesses))
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+	for i := range guesses {
+		next <- i
+	}
+	close(next)
+	wg.Wait()
+
+	for _, rank := range ranks {
+		r.Cache.Put(rank)
+	}
+	return ranks, first
+}
+
+// Sort sorts ranks best first by the named metric, breaking ties by guess.
+// Ranks that weren't simulated sort last by Average.
+func Sort(ranks []Rank, metric string) error {
+	var less func(a, b Rank) bool
+	switch metric {
+	case Entropy:
+		less = func(a, b Rank) bool { return a.Entropy > b.Entropy }
+	case Worst:
+		less = func(a, b Rank) bool { return a.Worst < b.Worst }
+	case Expected:
+		less = func(a, b Rank) bool { return a.Expected < b.Expected }
+	case Average:
+		less = func(a, b Rank) bool {
+			if a.Simulated != b.Simulated {
+				return a.Simulated
+			}
+			return a.Average < b.Average
+		}
+	default:
+		return ValidMetric(metric)
+	}
+	sort.SliceStable(ranks, func(i, j int) bool {
+		a, b := ranks[i], ranks[j]
+		if less(a, b) {
+			return true
+		}
+		if less(b, a) {
+			return false
+		}
+		return a.Guess < b.Guess
+	})
+	return nil
+}
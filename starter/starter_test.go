@@ -0,0 +1,131 @@
+package starter
+
+import (
+	"math"
+	"reflect"
+	"testing"
+
+	"wordler/solver"
+	"wordler/wordlist"
+)
+
+var words = []string{"foo", "bar", "bam", "bay", "zap", "zoo"}
+
+func TestMeasure(t *testing.T) {
+	// "bam" splits the words into {foo, zoo}, {bar, bay}, {bam} and {zap}.
+	r := Measure(wordlist.New(words), "bam")
+	if want, got := 2, r.Worst; want != got {
+		t.Errorf("want worst %d, got %d", want, got)
+	}
+	if want, got := (2*2+2*2+1+1)/6.0, r.Expected; math.Abs(want-got) > 1e-9 {
+		t.Errorf("want expected %v, got %v", want, got)
+	}
+	if want, got := -2*(2/6.0)*math.Log2(2/6.0)-2*(1/6.0)*math.Log2(1/6.0), r.Entropy; math.Abs(want-got) > 1e-9 {
+		t.Errorf("want entropy %v, got %v", want, got)
+	}
+	if r.Simulated {
+		t.Error("want no simulation")
+	}
+}
+
+func TestSimulate(t *testing.T) {
+	s := solver.From(words)
+	average, max, err := Simulate(s, "zoo")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tree, err := solver.From(words).Tree()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if max < 1 || average < 1 {
+		t.Errorf("want at least one guess, got average %v and max %d", average, max)
+	}
+	if tree.Guess == "zoo" {
+		t.Fatal("want a first guess the solver wouldn't make")
+	}
+	if want, _ := tree.Stats(); want == average {
+		t.Errorf("opening with 'zoo' gave the solver's own average, %v", average)
+	}
+}
+
+func TestRanker(t *testing.T) {
+	r := &Ranker{
+		Solutions: wordlist.New(words),
+		NewSolver: func() (*solver.Solver, error) { return solver.From(words), nil },
+		Workers:   2,
+	}
+	calls := 0
+	r.Progress = func(done, total int) {
+		calls++
+		if total != len(words) || done > total {
+			t.Errorf("want progress out of %d, got %d/%d", len(words), done, total)
+		}
+	}
+	ranks := r.Measure(words)
+	if calls != len(words) {
+		t.Errorf("want %d progress reports, got %d", len(words), calls)
+	}
+	for i, rank := range ranks {
+		if want := Measure(wordlist.New(words), words[i]); !near(want, rank) {
+			t.Errorf("want %v, got %v", want, rank)
+		}
+	}
+
+	r.Progress = nil
+	ranks, err := r.Simulate(words[:2])
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, rank := range ranks {
+		average, max, _ := Simulate(solver.From(words), rank.Guess)
+		if !rank.Simulated || rank.Average != average || rank.MaxGuesses != max {
+			t.Errorf("want average %v and max %d, got %v", average, max, rank)
+		}
+	}
+}
+
+// near returns true if a and b are equal but for rounding.
+func near(a, b Rank) bool {
+	return a.Guess == b.Guess && a.Worst == b.Worst && a.Simulated == b.Simulated &&
+		math.Abs(a.Entropy-b.Entropy) < 1e-9 && math.Abs(a.Expected-b.Expected) < 1e-9
+}
+
+func TestSort(t *testing.T) {
+	ranks := []Rank{
+		{Guess: "a", Entropy: 1, Worst: 3, Expected: 2, Simulated: true, Average: 4},
+		{Guess: "b", Entropy: 2, Worst: 2, Expected: 3},
+		{Guess: "c", Entropy: 3, Worst: 2, Expected: 1, Simulated: true, Average: 3},
+	}
+	for metric, want := range map[string][]string{
+		Entropy:  {"c", "b", "a"},
+		Worst:    {"b", "c", "a"},
+		Expected: {"c", "a", "b"},
+		Average:  {"c", "a", "b"},
+	} {
+		if err := Sort(ranks, metric); err != nil {
+			t.Fatal(err)
+		}
+		var got []string
+		for _, r := range ranks {
+			got = append(got, r.Guess)
+		}
+		if !reflect.DeepEqual(want, got) {
+			t.Errorf("%s: want %v, got %v", metric, want, got)
+		}
+	}
+	if err := Sort(ranks, "luck"); err == nil {
+		t.Error("want error for an unknown metric")
+	}
+}
+
+func TestValidMetric(t *testing.T) {
+	for _, metric := range Metrics {
+		if err := ValidMetric(metric); err != nil {
+			t.Errorf("%s: want nil, got %v", metric, err)
+		}
+	}
+	if err := ValidMetric("luck"); err == nil {
+		t.Error("want error for an unknown metric")
+	}
+}
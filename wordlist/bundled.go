@@ -0,0 +1,84 @@
+package wordlist
+
+import (
+	"embed"
+	"errors"
+	"fmt"
+	"sort"
+)
+
+// NoBundledWordsErr is returned for word lengths with no bundled word lists.
+var NoBundledWordsErr = errors.New("no bundled word lists")
+
+//go:embed dict/answers-*.txt dict/guesses-*.txt
+var bundled embed.FS
+
+// bundledAnswers and bundledGuesses load the bundled word lists for each word
+// length. Answers are the common words a puzzle picks its solution from;
+// guesses are every word a puzzle accepts as a guess, answers included. The
+// Wordle dictionary serves as both for 5 letters.
+var (
+	bundledAnswers = map[int]DictionaryLoader{
+		4: bundledLoader("dict/answers-4.txt"),
+		5: EmbeddedLoader,
+		6: bundledLoader("dict/answers-6.txt"),
+		7: bundledLoader("dict/answers-7.txt"),
+	}
+	bundledGuesses = map[int]DictionaryLoader{
+		4: bundledLoader("dict/answers-4.txt", "dict/guesses-4.txt"),
+		5: EmbeddedLoader,
+		6: bundledLoader("dict/answers-6.txt", "dict/guesses-6.txt"),
+		7: bundledLoader("dict/answers-7.txt", "dict/guesses-7.txt"),
+	}
+)
+
+// BundledLengths returns the word lengths that have bundled word lists, in
+// increasing order.
+func BundledLengths() []int {
+	lengths := make([]int, 0, len(bundledAnswers))
+	for length := range bundledAnswers {
+		lengths = append(lengths, length)
+	}
+	sort.Ints(lengths)
+	return lengths
+}
+
+// NewAnswerLoader returns a DictionaryLoader for the bundled list of possible
+// solutions of the given length. It works on every platform.
+func NewAnswerLoader(length int) (DictionaryLoader, error) {
+	if l, ok := bundledAnswers[length]; ok {
+		return l, nil
+	}
+	return nil, fmt.Errorf("%d letters: %w; try one of %v", length, NoBundledWordsErr, BundledLengths())
+}
+
+// NewGuessLoader returns a DictionaryLoader for the bundled list of allowed
+// guesses of the given length, which includes every possible solution. It
+// works on every platform.
+func NewGuessLoader(length int) (DictionaryLoader, error) {
+	if l, ok := bundledGuesses[length]; ok {
+		return l, nil
+	}
+	return nil, fmt.Errorf("%d letters: %w; try one of %v", length, NoBundledWordsErr, BundledLengths())
+}
+
+// bundledLoader returns a DictionaryLoader that reads the named bundled files,
+// in order.
+func bundledLoader(names ...string) DictionaryLoader {
+	return &loader{read: func() ([]string, error) {
+		var words []string
+		for _, name := range names {
+			file, err := bundled.Open(name)
+			if err != nil {
+				return nil, err
+			}
+			w, err := readWords(file)
+			file.Close()
+			if err != nil {
+				return nil, err
+			}
+			words = append(words, w...)
+		}
+		return words, nil
+	}}
+}
@@ -0,0 +1,56 @@
+package wordlist
+
+import (
+	"errors"
+	"reflect"
+	"regexp"
+	"testing"
+)
+
+func TestBundledLengths(t *testing.T) {
+	if want, got := []int{4, 5, 6, 7}, BundledLengths(); !reflect.DeepEqual(want, got) {
+		t.Errorf("want %v, got %v", want, got)
+	}
+}
+
+func TestBundledLoaders(t *testing.T) {
+	for _, length := range BundledLengths() {
+		answers, err := NewAnswerLoader(length)
+		if err != nil {
+			t.Fatalf("%d letters: unexpected error %v", length, err)
+		}
+		guesses, err := NewGuessLoader(length)
+		if err != nil {
+			t.Fatalf("%d letters: unexpected error %v", length, err)
+		}
+		a, err := answers.Load()
+		if err != nil || a.Length() == 0 {
+			t.Fatalf("%d letters: want answers, got %d (err %v)", length, a.Length(), err)
+		}
+		g, err := guesses.Load()
+		if err != nil || g.Length() < a.Length() {
+			t.Fatalf("%d letters: want at least %d guesses, got %d (err %v)", length, a.Length(), g.Length(), err)
+		}
+
+		exp := regexp.MustCompile("^[a-z]+$")
+		for _, word := range g.Sorted() {
+			if len(word) != length || !exp.MatchString(word) {
+				t.Errorf("%d letters: invalid guess '%s'", length, word)
+			}
+		}
+		for _, word := range a.Sorted() {
+			if !g.Contains(word) {
+				t.Errorf("%d letters: answer '%s' is not a valid guess", length, word)
+			}
+		}
+	}
+}
+
+func TestBundledLoadersInvalidLength(t *testing.T) {
+	if _, err := NewAnswerLoader(3); !errors.Is(err, NoBundledWordsErr) {
+		t.Errorf("want %v, got %v", NoBundledWordsErr, err)
+	}
+	if _, err := NewGuessLoader(8); !errors.Is(err, NoBundledWordsErr) {
+		t.Errorf("want %v, got %v", NoBundledWordsErr, err)
+	}
+}
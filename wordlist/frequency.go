@@ -0,0 +1,71 @@
+package wordlist
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
+	"sync/atomic"
+)
+
+// FrequencyOption weights the words in a WordList by how often they're used;
+// see WordList.Frequency.
+type FrequencyOption struct {
+	Freq map[string]float64
+}
+
+// apply fulfills the Option interface
+func (f FrequencyOption) apply(w *WordList) {
+	if w == nil || w.words == nil {
+		return
+	}
+	w.words = &set{u: w.words.u.weighted(f.Freq), bits: w.words.bits, shared: atomic.LoadInt32(&w.words.shared)}
+}
+
+// key fulfills the Option interface; FrequencyOptions are identified by their
+// map.
+func (f FrequencyOption) key() string {
+	return fmt.Sprintf("frequency %p", f.Freq)
+}
+
+// LoadFrequencies reads word frequencies from r, one word per line followed by
+// whitespace and its frequency, e.g. "apple 12345". Blank lines and lines
+// starting with '#' are ignored; a word listed twice gets the sum of its
+// frequencies.
+func LoadFrequencies(r io.Reader) (map[string]float64, error) {
+	freq := make(map[string]float64)
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		fields := strings.Fields(text)
+		if len(fields) != 2 {
+			return nil, fmt.Errorf("line %d: want \"<word> <frequency>\", got %q", line, text)
+		}
+		f, err := strconv.ParseFloat(fields[1], 64)
+		if err != nil || f < 0 {
+			return nil, fmt.Errorf("line %d: invalid frequency %q", line, fields[1])
+		}
+		freq[fields[0]] += f
+	}
+	return freq, scanner.Err()
+}
+
+// LoadFrequencyFile reads word frequencies from the named file; see
+// LoadFrequencies.
+func LoadFrequencyFile(path string) (map[string]float64, error) {
+	file, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer file.Close()
+	freq, err := LoadFrequencies(file)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return freq, nil
+}
@@ -0,0 +1,99 @@
+package wordlist
+
+import (
+	"math"
+	"reflect"
+	"regexp"
+	"strings"
+	"testing"
+)
+
+func TestLoadFrequencies(t *testing.T) {
+	in := "# word counts\napple 120\n\naalii 0.5\napple 3\n"
+	want := map[string]float64{"apple": 123, "aalii": 0.5}
+	if got, err := LoadFrequencies(strings.NewReader(in)); err != nil {
+		t.Errorf("unexpected error %v", err)
+	} else if !reflect.DeepEqual(want, got) {
+		t.Errorf("want %v, got %v", want, got)
+	}
+
+	for _, in := range []string{"apple\n", "apple 1 2\n", "apple lots\n", "apple -1\n"} {
+		if got, err := LoadFrequencies(strings.NewReader(in)); err == nil {
+			t.Errorf("%q: want error, got %v", in, got)
+		}
+	}
+}
+
+func TestFrequency(t *testing.T) {
+	freq := map[string]float64{"ab": 8, "cd": 2, "zz": 100}
+	w := New([]string{"ab", "cd", "ce"}, FrequencyOption{freq})
+	cases := []struct {
+		word string
+		want float64
+	}{
+		{"ab", 8},
+		{"cd", 2},
+		{"ce", 2}, // missing words get the smallest frequency
+		{"zz", 0}, // not in the WordList
+	}
+	for _, c := range cases {
+		if got := w.Frequency(c.word); c.want != got {
+			t.Errorf("Frequency(%q): want %v, got %v", c.word, c.want, got)
+		}
+	}
+
+	// Frequencies survive filtering and cloning.
+	w = New([]string{"ab", "cd", "ce"}, FrequencyOption{freq}, DeleteOption{regexp.MustCompile("^c")})
+	if want, got := 8.0, w.Clone().Frequency("ab"); want != got {
+		t.Errorf("want %v, got %v", want, got)
+	}
+
+	if want, got := map[string]float64{"ab": 8}, w.Frequencies(); !reflect.DeepEqual(want, got) {
+		t.Errorf("want %v, got %v", want, got)
+	}
+
+	// Without frequencies, every word is equally likely.
+	w = New([]string{"ab", "cd"})
+	if want, got := 1.0, w.Frequency("cd"); want != got {
+		t.Errorf("want %v, got %v", want, got)
+	}
+	if got := w.Frequencies(); got != nil {
+		t.Errorf("want no frequencies, got %v", got)
+	}
+}
+
+func TestWeightedRandom(t *testing.T) {
+	w := New([]string{"foo", "bar", "zoo"}, FrequencyOption{map[string]float64{"foo": 1000, "bar": 1, "zoo": 0}})
+	seen := make(map[string]int)
+	for i := 0; i < 1000; i++ {
+		seen[w.Random()]++
+	}
+	if seen["zoo"] > 0 {
+		t.Errorf("want zero-frequency word never chosen, got %v", seen)
+	}
+	if seen["foo"] < 950 {
+		t.Errorf("want 'foo' chosen about 999 times in 1000, got %v", seen)
+	}
+}
+
+func TestWeightedStrategy(t *testing.T) {
+	words := []string{"ab", "cd", "ce"}
+	freq := FrequencyOption{map[string]float64{"ab": 8, "cd": 1, "ce": 1}}
+
+	// "ab" leaves 1 word; otherwise 2 remain.
+	if want, got := 5.0/3, New(words).ExpectedRemaining("ab"); math.Abs(want-got) > 1e-9 {
+		t.Errorf("uniform: want %v, got %v", want, got)
+	}
+	if want, got := 1.2, New(words, freq).ExpectedRemaining("ab"); math.Abs(want-got) > 1e-9 {
+		t.Errorf("weighted: want %v, got %v", want, got)
+	}
+
+	words = []string{"ab", "cd", "ef"}
+	if want, got := "ab", New(words).OptimalGuess(); want != got {
+		t.Errorf("uniform: want %v, got %v", want, got)
+	}
+	freq = FrequencyOption{map[string]float64{"ab": 1, "cd": 1, "ef": 10}}
+	if want, got := "ef", New(words, freq).OptimalGuess(); want != got {
+		t.Errorf("weighted: want %v, got %v", want, got)
+	}
+}
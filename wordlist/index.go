@@ -0,0 +1,251 @@
+package wordlist
+
+import (
+	"math/bits"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// bitset is a set of word indices into a universe.
+type bitset []uint64
+
+// newBitset returns an empty bitset with room for n words.
+func newBitset(n int) bitset {
+	return make(bitset, (n+63)/64)
+}
+
+// set adds i to the bitset.
+func (b bitset) set(i int) {
+	b[i/64] |= 1 << (uint(i) % 64)
+}
+
+// clear removes i from the bitset.
+func (b bitset) clear(i int) {
+	b[i/64] &^= 1 << (uint(i) % 64)
+}
+
+// has returns true if i is in the bitset.
+func (b bitset) has(i int) bool {
+	return b[i/64]&(1<<(uint(i)%64)) != 0
+}
+
+// and removes everything not in o from the bitset.
+func (b bitset) and(o bitset) {
+	for i := range b {
+		b[i] &= o[i]
+	}
+}
+
+// andNot removes everything in o from the bitset.
+func (b bitset) andNot(o bitset) {
+	for i := range b {
+		b[i] &^= o[i]
+	}
+}
+
+// count returns the number of items in the bitset.
+func (b bitset) count() int {
+	n := 0
+	for _, x := range b {
+		n += bits.OnesCount64(x)
+	}
+	return n
+}
+
+// countAnd returns the number of items in both b and o.
+func (b bitset) countAnd(o bitset) int {
+	n := 0
+	for i, x := range b {
+		n += bits.OnesCount64(x & o[i])
+	}
+	return n
+}
+
+// each calls f with each item in the bitset, in order.
+func (b bitset) each(f func(i int)) {
+	for i, x := range b {
+		for x != 0 {
+			f(i*64 + bits.TrailingZeros64(x))
+			x &= x - 1
+		}
+	}
+}
+
+// clone returns a copy of the bitset.
+func (b bitset) clone() bitset {
+	return append(bitset(nil), b...)
+}
+
+// universe is a fixed list of words with an index of their letters. WordLists
+// are subsets of a universe; many WordLists can share a universe, which is
+// never modified once it has been made.
+type universe struct {
+	words []string       // the words, without duplicates
+	index map[string]int // position of each word in words
+	freq  []float64      // freq[i] is the weight of words[i]; nil if all are equally likely
+
+	once     sync.Once    // guards building the letter index
+	alphabet map[rune]int // every letter in words, numbered from 0
+	at       [][]bitset   // at[i][l] holds words with letter number l at position i
+	counts   [][]bitset   // counts[l][n] holds words with more than n of letter number l
+	empty    bitset       // no words; returned for letters not in the index
+}
+
+// newUniverse makes a universe from the words in s.
+func newUniverse(s []string) *universe {
+	u := &universe{index: make(map[string]int, len(s))}
+	for _, word := range s {
+		if _, ok := u.index[word]; !ok {
+			u.index[word] = len(u.words)
+			u.words = append(u.words, word)
+		}
+	}
+	return u
+}
+
+// weighted returns a universe of the same words, weighted by freq. Words
+// missing from freq get the smallest weight in it, so they stay possible but
+// are least likely. An empty freq weights all words equally.
+func (u *universe) weighted(freq map[string]float64) *universe {
+	w := &universe{words: u.words, index: u.index}
+	if len(freq) == 0 {
+		return w
+	}
+	floor := -1.0
+	for _, f := range freq {
+		if f > 0 && (floor < 0 || f < floor) {
+			floor = f
+		}
+	}
+	if floor < 0 {
+		floor = 0
+	}
+	w.freq = make([]float64, len(u.words))
+	for i, word := range u.words {
+		if f, ok := freq[word]; ok {
+			w.freq[i] = f
+		} else {
+			w.freq[i] = floor
+		}
+	}
+	return w
+}
+
+// subset returns a universe holding only the words in b, keeping their
+// weights.
+func (u *universe) subset(b bitset) *universe {
+	s := &universe{index: make(map[string]int, b.count())}
+	b.each(func(i int) {
+		s.index[u.words[i]] = len(s.words)
+		s.words = append(s.words, u.words[i])
+		if u.freq != nil {
+			s.freq = append(s.freq, u.freq[i])
+		}
+	})
+	return s
+}
+
+// weight returns the weight of word number i.
+func (u *universe) weight(i int) float64 {
+	if u.freq == nil {
+		return 1
+	}
+	return u.freq[i]
+}
+
+// all returns a bitset holding every word in the universe.
+func (u *universe) all() bitset {
+	b := newBitset(len(u.words))
+	for i := range u.words {
+		b.set(i)
+	}
+	return b
+}
+
+// buildIndex indexes the letters of every word in the universe the first time
+// it's called.
+func (u *universe) buildIndex() {
+	u.once.Do(func() {
+		u.empty = newBitset(len(u.words))
+		u.alphabet = make(map[rune]int)
+		var (
+			seen    []int // how many of each letter the current word has
+			letters []int // the letters of the current word
+		)
+		for i, word := range u.words {
+			letters = letters[:0]
+			j := -1 // position of c, counted in letters rather than bytes
+			for _, c := range word {
+				j++
+				l, ok := u.alphabet[c]
+				if !ok {
+					l = len(u.alphabet)
+					u.alphabet[c] = l
+					u.counts = append(u.counts, nil)
+					seen = append(seen, 0)
+				}
+				letters = append(letters, l)
+
+				for len(u.at) <= j {
+					u.at = append(u.at, nil)
+				}
+				for len(u.at[j]) <= l {
+					u.at[j] = append(u.at[j], nil)
+				}
+				if u.at[j][l] == nil {
+					u.at[j][l] = newBitset(len(u.words))
+				}
+				u.at[j][l].set(i)
+
+				if len(u.counts[l]) <= seen[l] {
+					u.counts[l] = append(u.counts[l], newBitset(len(u.words)))
+				}
+				u.counts[l][seen[l]].set(i)
+				seen[l]++
+			}
+			for _, l := range letters {
+				seen[l] = 0
+			}
+		}
+	})
+}
+
+// withLetterAt returns the words with letter c at position i.
+func (u *universe) withLetterAt(i int, c rune) bitset {
+	u.buildIndex()
+	l, ok := u.alphabet[c]
+	if !ok || i < 0 || i >= len(u.at) || l >= len(u.at[i]) || u.at[i][l] == nil {
+		return u.empty
+	}
+	return u.at[i][l]
+}
+
+// withLetter returns the words with at least n of letter c; n must be positive.
+func (u *universe) withLetter(c rune, n int) bitset {
+	u.buildIndex()
+	l, ok := u.alphabet[c]
+	if !ok || n > len(u.counts[l]) {
+		return u.empty
+	}
+	return u.counts[l][n-1]
+}
+
+var (
+	rngMu sync.Mutex
+	rng   = rand.New(rand.NewSource(time.Now().UnixNano()))
+)
+
+// random returns a random number in [0,n).
+func random(n int) int {
+	rngMu.Lock()
+	defer rngMu.Unlock()
+	return rng.Intn(n)
+}
+
+// randomFloat returns a random number in [0,f).
+func randomFloat(f float64) float64 {
+	rngMu.Lock()
+	defer rngMu.Unlock()
+	return rng.Float64() * f
+}
@@ -0,0 +1,194 @@
+package wordlist
+
+import (
+	"bufio"
+	"compress/gzip"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+
+	"wordler"
+)
+
+// DictionaryLoader is the interface for loading the dictionary into a WordList.
+type DictionaryLoader interface {
+	// Load loads the dictionary, filtered via the given options.
+	Load(options ...Option) (*WordList, error)
+}
+
+// Loader is the default DictionaryLoader, used by NewDictionary; it is an
+// exported variable so that tests can provide a platform-independent
+// substitution. Prefer passing a DictionaryLoader to LoadDictionary.
+var Loader DictionaryLoader = Normalized(NewPlatformLoader(), &Normalizer{})
+
+// NewDictionary returns a *WordList based on the dictionary returned by Loader.
+func NewDictionary(options ...Option) (*WordList, error) {
+	return LoadDictionary(nil, options...)
+}
+
+// LoadDictionary returns a *WordList based on the dictionary returned by l, or
+// by Loader if l is nil.
+func LoadDictionary(l DictionaryLoader, options ...Option) (*WordList, error) {
+	if l == nil {
+		l = Loader
+	}
+	return l.Load(options...)
+}
+
+// NewPlatformLoader returns a DictionaryLoader that reads the platform's
+// dictionary; loading fails if the platform has none. Its words are not
+// normalized; see Normalized.
+func NewPlatformLoader() DictionaryLoader {
+	return &loader{read: readPlatform}
+}
+
+// NewFileLoader returns a DictionaryLoader that reads the named file, one word
+// per line. The file may be gzip-compressed.
+func NewFileLoader(path string) DictionaryLoader {
+	return &loader{read: func() ([]string, error) {
+		file, err := os.Open(path)
+		if err != nil {
+			return nil, err
+		}
+		defer file.Close()
+		return readWords(file)
+	}}
+}
+
+// NewReaderLoader returns a DictionaryLoader that reads r, one word per line,
+// the first time it loads. The input may be gzip-compressed.
+func NewReaderLoader(r io.Reader) DictionaryLoader {
+	return &loader{read: func() ([]string, error) {
+		return readWords(r)
+	}}
+}
+
+// EmbeddedLoader loads the Wordle dictionary built into the program,
+// wordler.Dictionary; it works on every platform.
+var EmbeddedLoader DictionaryLoader = &loader{read: readEmbedded}
+
+// platformDictionaries are the places the platform's dictionary may be found.
+var platformDictionaries = []string{
+	"/usr/share/dict/words",
+	"/usr/dict/words",
+	"/usr/share/dict/web2",
+}
+
+// maxFiltered is how many sets of options a loader keeps the filtered words
+// of.
+const maxFiltered = 8
+
+// loader loads its words once, with read, and then filters them once for each
+// set of options; each Load returns a clone of the filtered WordList, so
+// loading again and again stays cheap. Only the maxFiltered most recently used
+// sets of options are kept, so callers loading with ever-changing options pay
+// for filtering each time, but don't grow the loader.
+type loader struct {
+	read func() ([]string, error)
+
+	once  sync.Once
+	words []string
+	err   error
+
+	mu       sync.Mutex
+	filtered map[string]*filtered // by optionsKey
+	keys     []string             // of filtered, least recently used first
+}
+
+// filtered is the WordList a loader made with options.
+type filtered struct {
+	options []Option // kept so that the keys of these options stay unique
+	words   *WordList
+}
+
+// Load fulfills the DictionaryLoader interface.
+func (l *loader) Load(options ...Option) (*WordList, error) {
+	l.once.Do(func() {
+		l.words, l.err = l.read()
+	})
+
+	key := optionsKey(options)
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	f, ok := l.filtered[key]
+	if ok {
+		for i, k := range l.keys {
+			if k == key {
+				l.keys = append(l.keys[:i], l.keys[i+1:]...)
+				break
+			}
+		}
+	} else {
+		f = &filtered{options: append([]Option(nil), options...), words: New(l.words, options...)}
+		if l.filtered == nil {
+			l.filtered = make(map[string]*filtered)
+		}
+		l.filtered[key] = f
+		if len(l.keys) == maxFiltered {
+			delete(l.filtered, l.keys[0])
+			l.keys = l.keys[1:]
+		}
+	}
+	l.keys = append(l.keys, key)
+	return f.words.Clone(), l.err
+}
+
+// optionsKey returns a key identifying options, in order.
+func optionsKey(options []Option) string {
+	keys := make([]string, len(options))
+	for i, o := range options {
+		keys[i] = o.key()
+	}
+	return strings.Join(keys, "\x00")
+}
+
+// readPlatform reads the first platform dictionary it finds.
+func readPlatform() ([]string, error) {
+	var first error
+	for _, path := range platformDictionaries {
+		file, err := os.Open(path)
+		if err != nil {
+			if first == nil {
+				first = err
+			}
+			continue
+		}
+		defer file.Close()
+		return readWords(file)
+	}
+	return nil, fmt.Errorf("no platform dictionary: %w", first)
+}
+
+// readEmbedded returns the embedded dictionary.
+func readEmbedded() ([]string, error) {
+	return append([]string(nil), wordler.Dictionary...), nil
+}
+
+// readWords reads one word per line from r, which may be gzip-compressed,
+// skipping blank lines.
+func readWords(r io.Reader) ([]string, error) {
+	in := bufio.NewReader(r)
+	if magic, err := in.Peek(2); err == nil && magic[0] == 0x1f && magic[1] == 0x8b {
+		z, err := gzip.NewReader(in)
+		if err != nil {
+			return nil, err
+		}
+		defer z.Close()
+		r = z
+	} else {
+		r = in
+	}
+
+	var words []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		word := strings.TrimSpace(scanner.Text())
+		if word == "" {
+			continue
+		}
+		words = append(words, word)
+	}
+	return words, scanner.Err()
+}
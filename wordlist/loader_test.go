@@ -0,0 +1,172 @@
+package wordlist
+
+import (
+	"bytes"
+	"compress/gzip"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+
+	"wordler"
+)
+
+// gzipped returns s, gzip-compressed.
+func gzipped(t *testing.T, s string) []byte {
+	var b bytes.Buffer
+	z := gzip.NewWriter(&b)
+	if _, err := z.Write([]byte(s)); err != nil {
+		t.Fatal(err)
+	}
+	if err := z.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return b.Bytes()
+}
+
+func TestReaderLoader(t *testing.T) {
+	const words = "foo\nbar\n\n  bam \nzoo\n"
+	want := New([]string{"foo", "bar", "bam", "zoo"})
+	for desc, in := range map[string][]byte{
+		"plain": []byte(words),
+		"gzip":  gzipped(t, words),
+	} {
+		t.Run(desc, func(t *testing.T) {
+			l := NewReaderLoader(bytes.NewReader(in))
+			got, err := l.Load()
+			if err != nil {
+				t.Fatalf("unexpected error %v", err)
+			}
+			if !want.Equals(got) {
+				t.Errorf("want %#v, got %#v", want, got)
+			}
+
+			// The reader is only read once; later loads apply their own options.
+			got, err = l.Load(KeepOnlyOption{regexp.MustCompile("^b")})
+			if want := New([]string{"bar", "bam"}); err != nil || !want.Equals(got) {
+				t.Errorf("want %#v, got %#v (err %v)", want, got, err)
+			}
+		})
+	}
+}
+
+func TestFileLoader(t *testing.T) {
+	dir := t.TempDir()
+	plain := filepath.Join(dir, "words")
+	compressed := filepath.Join(dir, "words.gz")
+	if err := os.WriteFile(plain, []byte("foo\nbar\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(compressed, gzipped(t, "foo\nbar\n"), 0644); err != nil {
+		t.Fatal(err)
+	}
+
+	want := New([]string{"foo", "bar"})
+	for _, path := range []string{plain, compressed} {
+		got, err := LoadDictionary(NewFileLoader(path))
+		if err != nil {
+			t.Errorf("%s: unexpected error %v", path, err)
+		} else if !want.Equals(got) {
+			t.Errorf("%s: want %#v, got %#v", path, want, got)
+		}
+	}
+
+	if got, err := NewFileLoader(filepath.Join(dir, "missing")).Load(); err == nil {
+		t.Errorf("want error, got %#v", got)
+	}
+}
+
+func TestEmbeddedLoader(t *testing.T) {
+	got, err := EmbeddedLoader.Load()
+	if err != nil {
+		t.Fatalf("unexpected error %v", err)
+	}
+	if want := New(wordler.Dictionary); !want.Equals(got) {
+		t.Errorf("want the Wordle dictionary, got %d words", got.Length())
+	}
+}
+
+func TestLoadDictionary(t *testing.T) {
+	was := Loader
+	defer func() { Loader = was }()
+	Loader = NewReaderLoader(strings.NewReader("foo\nbar\n"))
+
+	// A nil DictionaryLoader means Loader.
+	got, err := LoadDictionary(nil)
+	if want := New([]string{"foo", "bar"}); err != nil || !want.Equals(got) {
+		t.Errorf("want %#v, got %#v (err %v)", want, got, err)
+	}
+	got, err = LoadDictionary(NewReaderLoader(strings.NewReader("zoo\n")))
+	if want := New([]string{"zoo"}); err != nil || !want.Equals(got) {
+		t.Errorf("want %#v, got %#v (err %v)", want, got, err)
+	}
+}
+
+func TestLoaderCache(t *testing.T) {
+	l := NewReaderLoader(strings.NewReader("foo\nbar\nbam\nzoo\n"))
+	a, _ := l.Load(KeepOnlyOption{regexp.MustCompile("^b")})
+	a.KeepOnlyAt(2, 'r')
+
+	// The same options give the same words, untouched by changes to a.
+	b, _ := l.Load(KeepOnlyOption{regexp.MustCompile("^b")})
+	if want := New([]string{"bar", "bam"}); !want.Equals(b) {
+		t.Errorf("want %#v, got %#v", want, b)
+	}
+	if want := New([]string{"bar"}); !want.Equals(a) {
+		t.Errorf("want %#v, got %#v", want, a)
+	}
+	if a.words.u != b.words.u {
+		t.Error("want loads with the same options to share a universe")
+	}
+
+	// Different options, or the same options in a different order, don't.
+	for _, options := range [][]Option{
+		{DeleteOption{regexp.MustCompile("^b")}},
+		{KeepOnlyOption{regexp.MustCompile("^b")}, FrequencyOption{map[string]float64{"bar": 1}}},
+		{FrequencyOption{map[string]float64{"bar": 1}}, KeepOnlyOption{regexp.MustCompile("^b")}},
+	} {
+		c, _ := l.Load(options...)
+		if c.words.u == b.words.u {
+			t.Errorf("%v: want a different universe", optionsKey(options))
+		}
+	}
+}
+
+func TestLoaderCacheLimit(t *testing.T) {
+	l := NewReaderLoader(strings.NewReader("foo\nbar\nbam\nzoo\n")).(*loader)
+	first, _ := l.Load(KeepOnlyOption{regexp.MustCompile("^b")})
+	for i := 0; i < 2*maxFiltered; i++ {
+		l.Load(FrequencyOption{map[string]float64{"bar": float64(i)}})
+		// Keep using the first options, so they stay cached.
+		again, _ := l.Load(KeepOnlyOption{regexp.MustCompile("^b")})
+		if again.words.u != first.words.u {
+			t.Fatalf("%d: want the recently used options to stay cached", i)
+		}
+	}
+	if len(l.filtered) != maxFiltered || len(l.keys) != maxFiltered {
+		t.Errorf("want %d cached options, got %d (%d keys)", maxFiltered, len(l.filtered), len(l.keys))
+	}
+}
+
+// BenchmarkLoaderIterations loads the dictionary the way an iteration of
+// main does, once for the puzzler and once for the solver, and then plays with
+// the lists; its cost per iteration should be flat, however many iterations
+// run.
+func BenchmarkLoaderIterations(b *testing.B) {
+	l := NewReaderLoader(strings.NewReader(strings.Join(wordler.Dictionary, "\n")))
+	for i := 0; i < b.N; i++ {
+		option := KeepOnlyOption{regexp.MustCompile("^[a-z]{5}$")}
+		p, err := l.Load(option)
+		if err != nil {
+			b.Fatal(err)
+		}
+		remaining := p.Clone()
+		s, _ := l.Load(option)
+		for _, w := range []*WordList{remaining, s} {
+			w.KeepOnlyContaining('e', 1)
+			w.DeleteAt(4, 'e')
+			w.DeleteContaining('a', 1)
+		}
+	}
+}
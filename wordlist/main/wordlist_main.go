@@ -0,0 +1,107 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"wordler"
+	"wordler/wordlist"
+)
+
+func main() {
+	local := flag.Bool("local_dictionary", false, "use local dictionary in place of Wordle dictionary")
+	dictionary := flag.String("dictionary", "", "load words from this file, one per line (optionally gzip-compressed), in place of Wordle dictionary")
+	foldCase := flag.Bool("fold-case", false, "lowercase dictionary words with uppercase letters rather than dropping them")
+	stripAccents := flag.Bool("strip-accents", false, "strip accents from dictionary words rather than dropping them")
+	include := flag.String("include", "", "letters that must appear; repeat a letter to require it more than once")
+	exclude := flag.String("exclude", "", "letters that must not appear")
+	notAt := flag.String("not-at", "", "letters that must not appear at a position, e.g. '1:wl,3:t'")
+	count := flag.Bool("count", false, "print only the number of matches")
+	order := flag.String("sort", "alpha", "sort matches by 'alpha' or by letter-frequency 'weight'")
+	usage := flag.Usage
+	flag.Usage = func() {
+		usage()
+		fmt.Fprintf(flag.CommandLine.Output(), "\nPositional arguments are a query: a pattern such as '_a_er' followed by\n")
+		fmt.Fprintf(flag.CommandLine.Output(), "'+letters' to include, '-letters' to exclude and '!<position><letters>' to\n")
+		fmt.Fprintf(flag.CommandLine.Output(), "exclude letters from a position, e.g. '_a_er +t -bk !1w'.\n")
+	}
+	flag.Parse()
+
+	q, err := wordlist.ParseQuery(strings.Join(flag.Args(), " "))
+	if err != nil {
+		fmt.Println("ERROR: ", err)
+		os.Exit(2)
+	}
+	q.Include += *include
+	q.Exclude += *exclude
+	if *notAt != "" {
+		if q.NotAt == nil {
+			q.NotAt = make(map[int]string)
+		}
+		for _, term := range strings.Split(*notAt, ",") {
+			parts := strings.SplitN(term, ":", 2)
+			pos, err := strconv.Atoi(parts[0])
+			if len(parts) != 2 || err != nil {
+				fmt.Printf("ERROR: invalid --not-at term %q: want '<position>:<letters>'\n", term)
+				os.Exit(2)
+			}
+			q.NotAt[pos] += parts[1]
+		}
+	}
+	if *order != "alpha" && *order != "weight" {
+		fmt.Printf("ERROR: invalid --sort %q: want 'alpha' or 'weight'\n", *order)
+		os.Exit(2)
+	}
+
+	var dict *wordlist.WordList
+	if *local || *dictionary != "" {
+		normalizer := &wordlist.Normalizer{FoldCase: *foldCase, StripAccents: *stripAccents}
+		loader := wordlist.NewPlatformLoader()
+		if *dictionary != "" {
+			loader = wordlist.NewFileLoader(*dictionary)
+		}
+		if dict, err = wordlist.Normalized(loader, normalizer).Load(); err != nil {
+			fmt.Println("ERROR: ", err)
+			os.Exit(1)
+		}
+		fmt.Fprintf(os.Stderr, "Dictionary: %v\n", normalizer.Report)
+	} else if length := utf8.RuneCountInString(q.Pattern); length != 0 && length != wordler.DEFAULT_WORD_LENGTH {
+		// Other lengths come from the bundled word lists.
+		loader, err := wordlist.NewGuessLoader(length)
+		if err != nil {
+			fmt.Println("ERROR: ", err)
+			os.Exit(2)
+		}
+		if dict, err = loader.Load(); err != nil {
+			fmt.Println("ERROR: ", err)
+			os.Exit(1)
+		}
+	} else {
+		dict = wordlist.New(wordler.Dictionary)
+	}
+
+	matches, err := dict.Query(q)
+	if err != nil {
+		fmt.Println("ERROR: ", err)
+		os.Exit(2)
+	}
+	if *count {
+		fmt.Println(matches.Length())
+		return
+	}
+
+	words := matches.Sorted()
+	if *order == "weight" {
+		weights := matches.Weights()
+		sort.SliceStable(words, func(i, j int) bool { return weights[words[i]] > weights[words[j]] })
+	}
+	for _, word := range words {
+		fmt.Println(word)
+	}
+	fmt.Printf("%d matches for %q\n", len(words), q)
+}
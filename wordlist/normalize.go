@@ -0,0 +1,147 @@
+package wordlist
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// Normalizer cleans up the words a DictionaryLoader reads: it drops words
+// containing anything but letters, folds or drops words with uppercase letters
+// (usually proper nouns), strips or drops diacritics, and drops duplicates.
+type Normalizer struct {
+	FoldCase     bool // lowercase words with uppercase letters; otherwise drop them
+	StripAccents bool // replace accented letters with their base letter; otherwise drop the word
+
+	// Report counts what happened to the words; it is filled in when the
+	// dictionary is first loaded.
+	Report NormalizationReport
+}
+
+// NormalizationReport counts the words a Normalizer read, changed and dropped.
+type NormalizationReport struct {
+	Read, Kept       int // words read, and words kept
+	Folded, Stripped int // words kept after case folding, or stripping accents
+
+	// Words dropped, and why.
+	Uppercase, Accented, NonLetter, Duplicate int
+}
+
+// Dropped returns the number of words dropped.
+func (r NormalizationReport) Dropped() int {
+	return r.Uppercase + r.Accented + r.NonLetter + r.Duplicate
+}
+
+// String fulfills the fmt.Stringer interface.
+func (r NormalizationReport) String() string {
+	return fmt.Sprintf("kept %d of %d words (%d case-folded, %d accents stripped); dropped %d: %d uppercase, %d accented, %d non-letter, %d duplicate",
+		r.Kept, r.Read, r.Folded, r.Stripped, r.Dropped(), r.Uppercase, r.Accented, r.NonLetter, r.Duplicate)
+}
+
+// Normalize returns the normalized words, in order, and adds them to n.Report.
+func (n *Normalizer) Normalize(words []string) []string {
+	seen := make(map[string]bool, len(words))
+	var normal []string
+	for _, word := range words {
+		n.Report.Read++
+		if strings.IndexFunc(word, func(r rune) bool { return !unicode.IsLetter(r) }) >= 0 {
+			n.Report.NonLetter++
+			continue
+		}
+
+		folded := false
+		if strings.IndexFunc(word, unicode.IsUpper) >= 0 {
+			if !n.FoldCase {
+				n.Report.Uppercase++
+				continue
+			}
+			word, folded = strings.ToLower(word), true
+		}
+
+		stripped := false
+		if strings.IndexFunc(word, isAccented) >= 0 {
+			if !n.StripAccents {
+				n.Report.Accented++
+				continue
+			}
+			word, stripped = strings.Map(stripAccent, word), true
+		}
+
+		if seen[word] {
+			n.Report.Duplicate++
+			continue
+		}
+		seen[word] = true
+		if folded {
+			n.Report.Folded++
+		}
+		if stripped {
+			n.Report.Stripped++
+		}
+		n.Report.Kept++
+		normal = append(normal, word)
+	}
+	return normal
+}
+
+// Normalized returns a DictionaryLoader that loads the words l does, normalized
+// by n.
+func Normalized(l DictionaryLoader, n *Normalizer) DictionaryLoader {
+	read := func() ([]string, error) {
+		w, err := l.Load()
+		return w.list(), err
+	}
+	if l, ok := l.(*loader); ok {
+		// Read the raw words, so that n sees any duplicates.
+		read = l.read
+	}
+	return &loader{read: func() ([]string, error) {
+		words, err := read()
+		return n.Normalize(words), err
+	}}
+}
+
+// accents maps each accented lowercase letter to its base letter.
+var accents = func() map[rune]rune {
+	m := make(map[rune]rune)
+	for base, accented := range map[rune]string{
+		'a': "àáâãäåāăą",
+		'c': "çćĉċč",
+		'd': "ď",
+		'e': "èéêëēĕėęě",
+		'g': "ĝğġģ",
+		'h': "ĥ",
+		'i': "ìíîïĩīĭį",
+		'j': "ĵ",
+		'k': "ķ",
+		'l': "ĺļľ",
+		'n': "ñńņňŉ",
+		'o': "òóôõöøōŏő",
+		'r': "ŕŗř",
+		's': "śŝşš",
+		't': "ţť",
+		'u': "ùúûüũūŭůűų",
+		'w': "ŵ",
+		'y': "ýÿŷ",
+		'z': "źżž",
+	} {
+		for _, r := range accented {
+			m[r] = base
+		}
+	}
+	return m
+}()
+
+// isAccented returns true if r is an accented letter.
+func isAccented(r rune) bool {
+	_, ok := accents[unicode.ToLower(r)]
+	return ok
+}
+
+// stripAccent returns r's base letter if r is accented, or else r.
+func stripAccent(r rune) rune {
+	if base, ok := accents[r]; ok {
+		return base
+	}
+	return r
+}
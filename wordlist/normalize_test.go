@@ -0,0 +1,75 @@
+package wordlist
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestNormalize(t *testing.T) {
+	words := []string{"apple", "Paris", "don't", "café", "Émile", "r2d2", "apple", "paris", "naïve", "ß"}
+	cases := []struct {
+		desc   string
+		n      Normalizer
+		want   []string
+		report NormalizationReport
+	}{
+		{
+			desc:   "default",
+			want:   []string{"apple", "paris", "ß"},
+			report: NormalizationReport{Read: 10, Kept: 3, Uppercase: 2, Accented: 2, NonLetter: 2, Duplicate: 1},
+		}, {
+			desc:   "fold case",
+			n:      Normalizer{FoldCase: true},
+			want:   []string{"apple", "paris", "ß"},
+			report: NormalizationReport{Read: 10, Kept: 3, Folded: 1, Accented: 3, NonLetter: 2, Duplicate: 2},
+		}, {
+			desc:   "strip accents",
+			n:      Normalizer{StripAccents: true},
+			want:   []string{"apple", "cafe", "paris", "naive", "ß"},
+			report: NormalizationReport{Read: 10, Kept: 5, Stripped: 2, Uppercase: 2, NonLetter: 2, Duplicate: 1},
+		}, {
+			desc:   "fold case and strip accents",
+			n:      Normalizer{FoldCase: true, StripAccents: true},
+			want:   []string{"apple", "paris", "cafe", "emile", "naive", "ß"},
+			report: NormalizationReport{Read: 10, Kept: 6, Folded: 2, Stripped: 3, NonLetter: 2, Duplicate: 2},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.desc, func(t *testing.T) {
+			n := c.n
+			if got := n.Normalize(words); !reflect.DeepEqual(got, c.want) {
+				t.Errorf("want %q, got %q", c.want, got)
+			}
+			if n.Report != c.report {
+				t.Errorf("want %+v, got %+v", c.report, n.Report)
+			}
+			if got, want := n.Report.Read, n.Report.Kept+n.Report.Dropped(); got != want {
+				t.Errorf("read %d words, but kept + dropped = %d", got, want)
+			}
+		})
+	}
+}
+
+func TestNormalized(t *testing.T) {
+	n := &Normalizer{FoldCase: true}
+	l := Normalized(NewReaderLoader(strings.NewReader("foo\nFoo\nbar's\nbar\n")), n)
+	got, err := l.Load()
+	if want := New([]string{"foo", "bar"}); err != nil || !want.Equals(got) {
+		t.Errorf("want %#v, got %#v (err %v)", want, got, err)
+	}
+	if want := (NormalizationReport{Read: 4, Kept: 2, NonLetter: 1, Duplicate: 1}); n.Report != want {
+		t.Errorf("want %+v, got %+v", want, n.Report)
+	}
+
+	// Any DictionaryLoader can be normalized.
+	n = &Normalizer{}
+	got, err = Normalized(Normalized(NewReaderLoader(strings.NewReader("Foo\nbar\n")), &Normalizer{FoldCase: true}), n).Load()
+	if want := New([]string{"foo", "bar"}); err != nil || !want.Equals(got) {
+		t.Errorf("want %#v, got %#v (err %v)", want, got, err)
+	}
+	if want := (NormalizationReport{Read: 2, Kept: 2}); n.Report != want {
+		t.Errorf("want %+v, got %+v", want, n.Report)
+	}
+}
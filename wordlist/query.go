@@ -0,0 +1,169 @@
+package wordlist
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+)
+
+// Query selects words from a WordList with Wordle-style clues.
+type Query struct {
+	// Pattern holds the letters known to be at each position, with '_' or
+	// '.' for unknown letters; "_a_er" matches "water" and "baker". An empty
+	// Pattern matches words of any length.
+	Pattern string
+	// Include holds letters that must appear somewhere; a letter repeated n
+	// times must appear at least n times.
+	Include string
+	// Exclude holds letters that must not appear at all.
+	Exclude string
+	// NotAt holds letters that must not appear at a position, counting from 1.
+	NotAt map[int]string
+}
+
+// ParseQuery parses a compact query: a pattern followed by any of "+letters"
+// to include, "-letters" to exclude and "!<position><letters>" to exclude
+// letters from a position. For example, "_a_er +t -bk !1w" matches words like
+// "tater" and "later" but not "water" or "baker".
+func ParseQuery(s string) (*Query, error) {
+	q := &Query{}
+	for i, field := range strings.Fields(s) {
+		switch field[0] {
+		case '+':
+			q.Include += field[1:]
+		case '-':
+			q.Exclude += field[1:]
+		case '!':
+			end := strings.IndexFunc(field[1:], func(r rune) bool { return r < '0' || r > '9' }) + 1
+			if end <= 1 {
+				return nil, fmt.Errorf("invalid query term %q: want \"!<position><letters>\"", field)
+			}
+			pos, _ := strconv.Atoi(field[1:end])
+			if q.NotAt == nil {
+				q.NotAt = make(map[int]string)
+			}
+			q.NotAt[pos] += field[end:]
+		default:
+			if i != 0 {
+				return nil, fmt.Errorf("invalid query term %q: the pattern must come first", field)
+			}
+			q.Pattern = field
+		}
+	}
+	return q, nil
+}
+
+// String fulfills the fmt.Stringer interface; the result can be parsed by
+// ParseQuery.
+func (q *Query) String() string {
+	var terms []string
+	if q.Pattern != "" {
+		terms = append(terms, q.Pattern)
+	}
+	if q.Include != "" {
+		terms = append(terms, "+"+q.Include)
+	}
+	if q.Exclude != "" {
+		terms = append(terms, "-"+q.Exclude)
+	}
+	var positions []int
+	for pos := range q.NotAt {
+		positions = append(positions, pos)
+	}
+	sort.Ints(positions)
+	for _, pos := range positions {
+		terms = append(terms, fmt.Sprintf("!%d%s", pos, q.NotAt[pos]))
+	}
+	return strings.Join(terms, " ")
+}
+
+// Options returns the Options that keep only the words matching q.
+func (q *Query) Options() ([]Option, error) {
+	var options []Option
+	length := utf8.RuneCountInString(q.Pattern)
+	required := make(map[rune]int) // letters that must appear, and how many times
+
+	if q.Pattern != "" {
+		var b strings.Builder
+		b.WriteString("^")
+		for _, c := range q.Pattern {
+			if c == '_' || c == '.' {
+				b.WriteString(".")
+			} else {
+				b.WriteString(regexp.QuoteMeta(string(c)))
+				required[c]++
+			}
+		}
+		b.WriteString("$")
+		options = append(options, KeepOnlyOption{regexp.MustCompile(b.String())})
+	}
+
+	include := make(map[rune]int)
+	for _, c := range q.Include {
+		include[c]++
+	}
+	for _, c := range SortedLetters(include) {
+		if include[c] > required[c] {
+			required[c] = include[c]
+		}
+		options = append(options, KeepOnlyOption{regexp.MustCompile(fmt.Sprintf("(?:%s.*){%d}", regexp.QuoteMeta(string(c)), include[c]))})
+	}
+
+	if q.Exclude != "" {
+		for _, c := range q.Exclude {
+			if required[c] > 0 {
+				return nil, fmt.Errorf("invalid query %q: '%c' is both required and excluded", q, c)
+			}
+		}
+		options = append(options, DeleteOption{regexp.MustCompile("[" + QuoteClass(q.Exclude) + "]")})
+	}
+
+	for pos, letters := range q.NotAt {
+		if pos < 1 || (length > 0 && pos > length) {
+			return nil, fmt.Errorf("invalid query %q: no position %d", q, pos)
+		}
+		if letters == "" {
+			continue
+		}
+		options = append(options, DeleteOption{regexp.MustCompile(fmt.Sprintf("^.{%d}[%s]", pos-1, QuoteClass(letters)))})
+	}
+	return options, nil
+}
+
+// Query returns a new WordList holding the words in w that match q.
+func (w *WordList) Query(q *Query) (*WordList, error) {
+	options, err := q.Options()
+	if err != nil {
+		return nil, err
+	}
+	matches := w.Clone()
+	for _, o := range options {
+		o.apply(matches)
+	}
+	return matches, nil
+}
+
+// QuoteClass escapes letters for use in a regexp character class.
+func QuoteClass(letters string) string {
+	var b strings.Builder
+	for _, c := range letters {
+		if strings.ContainsRune(`\]^-[`, c) {
+			b.WriteRune('\\')
+		}
+		b.WriteRune(c)
+	}
+	return b.String()
+}
+
+// SortedLetters returns the letters that are keys of m, in order.
+func SortedLetters[V any](m map[rune]V) []rune {
+	letters := make([]rune, 0, len(m))
+	for c := range m {
+		letters = append(letters, c)
+	}
+	sort.Slice(letters, func(i, j int) bool { return letters[i] < letters[j] })
+	return letters
+}
@@ -0,0 +1,93 @@
+package wordlist
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestParseQuery(t *testing.T) {
+	cases := []struct {
+		query string
+		want  *Query
+		err   bool
+	}{
+		{"_a_er", &Query{Pattern: "_a_er"}, false},
+		{"", &Query{}, false},
+		{"+t", &Query{Include: "t"}, false},
+		{"_a_er +t -bk !1w", &Query{Pattern: "_a_er", Include: "t", Exclude: "bk", NotAt: map[int]string{1: "w"}}, false},
+		{".a.er +t +s -b -k !1wl !1h !3t", &Query{Pattern: ".a.er", Include: "ts", Exclude: "bk", NotAt: map[int]string{1: "wlh", 3: "t"}}, false},
+		{"+t _a_er", nil, true},
+		{"_a_er !w", nil, true},
+	}
+
+	for _, c := range cases {
+		got, err := ParseQuery(c.query)
+		if c.err {
+			if err == nil {
+				t.Errorf("ParseQuery(%q): want error, got %#v", c.query, got)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("ParseQuery(%q): unexpected error %v", c.query, err)
+		} else if !reflect.DeepEqual(c.want, got) {
+			t.Errorf("ParseQuery(%q): want %#v, got %#v", c.query, c.want, got)
+		}
+	}
+
+	// String and ParseQuery round-trip.
+	q := &Query{Pattern: "_a_er", Include: "t", Exclude: "bk", NotAt: map[int]string{3: "t", 1: "w"}}
+	if want, got := "_a_er +t -bk !1w !3t", q.String(); want != got {
+		t.Errorf("want %q, got %q", want, got)
+	}
+	if got, err := ParseQuery(q.String()); err != nil || !reflect.DeepEqual(q, got) {
+		t.Errorf("want %#v, got %#v (err %v)", q, got, err)
+	}
+}
+
+func TestQuery(t *testing.T) {
+	baseList := []string{"water", "baker", "later", "tater", "hater", "eater", "paper", "wafer", "abate", "tatty"}
+	cases := []struct {
+		query string
+		want  []string
+		err   bool
+	}{
+		{"_a_er", []string{"water", "baker", "later", "tater", "hater", "eater", "paper", "wafer"}, false},
+		{".a.er", []string{"water", "baker", "later", "tater", "hater", "eater", "paper", "wafer"}, false},
+		{"_a_er -bk", []string{"water", "later", "tater", "hater", "eater", "paper", "wafer"}, false},
+		{"_a_er +t -bk !1w", []string{"later", "tater", "hater", "eater"}, false},
+		{"+tt", []string{"tater", "tatty"}, false},
+		{"+ttt", []string{"tatty"}, false},
+		{"+t !1t", []string{"water", "later", "hater", "eater", "abate"}, false},
+		{"____", nil, false},
+		{"_a_er -a", nil, true},
+		{"_a_er +w -w", nil, true},
+		{"_a_er !6r", nil, true},
+	}
+
+	for _, c := range cases {
+		t.Run(c.query, func(t *testing.T) {
+			q, err := ParseQuery(c.query)
+			if err != nil {
+				t.Fatalf("ParseQuery(%q): %v", c.query, err)
+			}
+			w := New(baseList)
+			got, err := w.Query(q)
+			if c.err {
+				if err == nil {
+					t.Errorf("want error, got %#v", got)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error %v", err)
+			}
+			if want := New(c.want); !want.Equals(got) {
+				t.Errorf("want %#v, got %#v", want, got)
+			}
+			if !New(baseList).Equals(w) {
+				t.Errorf("Query modified its WordList: %#v", w)
+			}
+		})
+	}
+}
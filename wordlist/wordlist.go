@@ -1,76 +1,57 @@
 package wordlist
 
 import (
-	"bufio"
-	"os"
+	"fmt"
 	"reflect"
 	"regexp"
-	"sync"
-	"unicode"
+	"sort"
+	"sync/atomic"
+
+	"wordler"
 )
 
+// WordList is a list of words. A WordList is a subset of a fixed universe of
+// words; the universe indexes each word's letters so that WordLists can be
+// filtered by letter position and letter count with bitwise operations.
 type WordList struct {
-	words map[string]bool
-}
-
-// Loader is the DictionaryLoader; it is an exported variable so that tests can
-// provide a platform-independent substitution.
-var Loader DictionaryLoader = &platformLoader{}
-
-// NewDictionary returns a *WordList based on the dictionary returned by Loader.
-func NewDictionary(options ...Option) (*WordList, error) {
-	return Loader.Load(options...)
+	words *set
 }
 
-// DictionaryLoader is the interface for loading the dictionary into a WordList.
-type DictionaryLoader interface {
-	// Load loads the dictionary, filtered via the given options.
-	Load(options ...Option) (*WordList, error)
+// set is a subset of the words in a universe.
+type set struct {
+	u      *universe
+	bits   bitset
+	shared int32 // nonzero if bits may be shared with other sets; copy them before changing them
 }
 
-// platformLoader loads dictionary on this platform.
-type platformLoader struct {
-	once           sync.Once
-	fullDictionary []string
-	err            error
+// share marks the set's bits as shared. Cloning only reads a WordList, so this
+// is safe to do from many goroutines at once.
+func (s *set) share() {
+	atomic.StoreInt32(&s.shared, 1)
 }
 
-// TODO: make this platform-independent via goos.Is*
-func (p *platformLoader) Load(options ...Option) (*WordList, error) {
-	p.once.Do(func() {
-		var file *os.File
-		file, p.err = os.Open(`/usr/share/dict/words`)
-		if p.err != nil {
-			return
-		}
-		defer file.Close()
-
-		scanner := bufio.NewScanner(file)
-	SCAN:
-		for scanner.Scan() {
-			word := scanner.Text()
-			for _, c := range word {
-				if unicode.IsUpper(c) {
-					continue SCAN
-				}
-			}
-			p.fullDictionary = append(p.fullDictionary, word)
-		}
-		p.err = scanner.Err()
-	})
-	return New(p.fullDictionary, options...), p.err
+// own returns the set's bits, first copying them if they're shared, so that
+// they can be changed.
+func (s *set) own() bitset {
+	if atomic.LoadInt32(&s.shared) != 0 {
+		s.bits = s.bits.clone()
+		atomic.StoreInt32(&s.shared, 0)
+	}
+	return s.bits
 }
 
 // New creates a new WordList containing the words in s.
 func New(s []string, options ...Option) *WordList {
-	m := make(map[string]bool, len(s))
-	for _, word := range s {
-		m[word] = true
-	}
-	w := &WordList{m}
+	u := newUniverse(s)
+	w := &WordList{&set{u: u, bits: u.all()}}
 	for _, o := range options {
 		o.apply(w)
 	}
+	if len(options) > 0 && w.Length() < len(u.words) {
+		// Don't carry filtered-out words around in the universe.
+		u = w.words.u.subset(w.words.bits)
+		w = &WordList{&set{u: u, bits: u.all()}}
+	}
 	return w
 }
 
@@ -84,26 +65,58 @@ func (this *WordList) Equals(that *WordList) bool {
 		return false
 	case that == nil:
 		return this == nil
+	case this.Length() == 0:
+		return true
+	case this.words.u == that.words.u:
+		return reflect.DeepEqual(this.words.bits, that.words.bits)
 	}
 
-	return reflect.DeepEqual(this.words, that.words)
+	for _, word := range this.list() {
+		if !that.Contains(word) {
+			return false
+		}
+	}
+	return true
 }
 
-// Clone the wordlist.
+// GoString fulfills the fmt.GoStringer interface so that %#v prints the words
+// in the WordList.
+func (w *WordList) GoString() string {
+	if w == nil {
+		return "(*wordlist.WordList)(nil)"
+	}
+	return fmt.Sprintf("wordlist.New(%#v)", w.Sorted())
+}
+
+// Clone the wordlist. The clone shares the WordList's words until either of
+// them is changed, so cloning is cheap. A WordList may be cloned from many
+// goroutines at once, as long as none of them changes it.
 func (w *WordList) Clone() *WordList {
-	m := make(map[string]bool, len(w.words))
-	for word := range w.words {
-		m[word] = true
+	if w.Length() == 0 {
+		return New(nil)
 	}
-	return &WordList{m}
+	w.words.share()
+	return &WordList{&set{u: w.words.u, bits: w.words.bits, shared: 1}}
 }
 
 // Length returns the number of words in the list.
 func (w *WordList) Length() int {
-	if w == nil {
+	if w == nil || w.words == nil {
 		return 0
 	}
-	return len(w.words)
+	return w.words.bits.count()
+}
+
+// list returns the words in the list in the order of their universe.
+func (w *WordList) list() []string {
+	if w.Length() == 0 {
+		return nil
+	}
+	list := make([]string, 0, w.Length())
+	w.words.bits.each(func(i int) {
+		list = append(list, w.words.u.words[i])
+	})
+	return list
 }
 
 // Delete removes all elements that match the given Regexp.
@@ -119,63 +132,209 @@ func (w *WordList) KeepOnly(r *regexp.Regexp) {
 // filter WordList based on r; if omit is true, delete matching items. If omit
 // is false, keep matching items.
 func (w *WordList) filter(r *regexp.Regexp, omit bool) {
-	if w == nil {
+	if w.Length() == 0 {
+		return
+	}
+	bits := w.words.own()
+	bits.each(func(i int) {
+		if omit == r.MatchString(w.words.u.words[i]) {
+			bits.clear(i)
+		}
+	})
+}
+
+// KeepOnlyFunc removes all words for which keep returns false.
+func (w *WordList) KeepOnlyFunc(keep func(word string) bool) {
+	if w.Length() == 0 {
 		return
 	}
-	for word, _ := range w.words {
-		if omit == r.MatchString(word) {
-			delete(w.words, word)
+	bits := w.words.own()
+	bits.each(func(i int) {
+		if !keep(w.words.u.words[i]) {
+			bits.clear(i)
 		}
+	})
+}
+
+// KeepOnlyAt removes all words that don't have letter c at position i
+// (counting from 0).
+func (w *WordList) KeepOnlyAt(i int, c rune) {
+	if w.Length() > 0 {
+		w.words.own().and(w.words.u.withLetterAt(i, c))
+	}
+}
+
+// DeleteAt removes all words that have letter c at position i (counting from
+// 0).
+func (w *WordList) DeleteAt(i int, c rune) {
+	if w.Length() > 0 {
+		w.words.own().andNot(w.words.u.withLetterAt(i, c))
+	}
+}
+
+// KeepOnlyContaining removes all words that have fewer than n of letter c.
+func (w *WordList) KeepOnlyContaining(c rune, n int) {
+	if w.Length() > 0 && n > 0 {
+		w.words.own().and(w.words.u.withLetter(c, n))
+	}
+}
+
+// DeleteContaining removes all words that have n or more of letter c.
+func (w *WordList) DeleteContaining(c rune, n int) {
+	switch {
+	case w.Length() == 0:
+	case n <= 0:
+		w.words.bits = newBitset(len(w.words.u.words))
+		atomic.StoreInt32(&w.words.shared, 0)
+	default:
+		w.words.own().andNot(w.words.u.withLetter(c, n))
 	}
 }
 
 // Contains returns true if word is in the WordList.
 func (w *WordList) Contains(word string) bool {
-	if w == nil {
+	if w.Length() == 0 {
 		return false
 	}
-	return w.words[word]
+	i, ok := w.words.u.index[word]
+	return ok && w.words.bits.has(i)
 }
 
-// Random returns a random word from the WordList
+// Random returns a random word from the WordList. If the WordList has
+// frequencies, words are drawn in proportion to them.
 func (w *WordList) Random() string {
-	// map iteration goes in a random order!
-	if w != nil {
-		for guess, _ := range w.words {
-			return guess
+	n := w.Length()
+	if n == 0 {
+		return ""
+	}
+	u := w.words.u
+	if total := w.mass(); u.freq != nil && total > 0 {
+		var word, last string
+		r := randomFloat(total)
+		w.words.bits.each(func(i int) {
+			if word != "" || u.freq[i] <= 0 {
+				return
+			}
+			if r < u.freq[i] {
+				word = u.words[i]
+			}
+			r -= u.freq[i]
+			last = u.words[i]
+		})
+		if word == "" {
+			word = last // r fell past the end by rounding error
 		}
+		return word
+	}
+
+	var word string
+	r := random(n)
+	w.words.bits.each(func(i int) {
+		if r == 0 {
+			word = u.words[i]
+		}
+		r--
+	})
+	return word
+}
+
+// Frequency returns the weight of word as a possible solution: its frequency
+// if the WordList has frequencies, 1 if not, and 0 if word isn't in the
+// WordList.
+func (w *WordList) Frequency(word string) float64 {
+	if w.Length() == 0 {
+		return 0
 	}
-	return ""
+	i, ok := w.words.u.index[word]
+	if !ok || !w.words.bits.has(i) {
+		return 0
+	}
+	return w.words.u.weight(i)
+}
+
+// Frequencies returns the Frequency of each word in the WordList, or nil if
+// the WordList has no frequencies.
+func (w *WordList) Frequencies() map[string]float64 {
+	if w.Length() == 0 || w.words.u.freq == nil {
+		return nil
+	}
+	freq := make(map[string]float64, w.Length())
+	w.words.bits.each(func(i int) {
+		freq[w.words.u.words[i]] = w.words.u.freq[i]
+	})
+	return freq
+}
+
+// mass returns the total weight of the words in the WordList.
+func (w *WordList) mass() float64 {
+	if w.Length() == 0 {
+		return 0
+	}
+	u := w.words.u
+	if u.freq == nil {
+		return float64(w.Length())
+	}
+	total := 0.0
+	w.words.bits.each(func(i int) {
+		total += u.freq[i]
+	})
+	return total
+}
+
+// Buckets partitions the WordList by the response each word would give to
+// guess, returning how many words give each response.
+func (w *WordList) Buckets(guess string) map[string]int {
+	buckets := make(map[string]int)
+	for _, word := range w.list() {
+		buckets[wordler.Score(guess, word)]++
+	}
+	return buckets
+}
+
+// ExpectedRemaining returns the number of words expected to remain in the
+// WordList after guess is scored. Each word's chance of being the solution is
+// its Frequency, so without frequencies all words are equally likely.
+func (w *WordList) ExpectedRemaining(guess string) float64 {
+	total := w.mass()
+	if total == 0 {
+		return 0
+	}
+	words := make(map[string]int)    // words in each bucket
+	mass := make(map[string]float64) // total weight of each bucket
+	u := w.words.u
+	w.words.bits.each(func(i int) {
+		response := wordler.Score(guess, u.words[i])
+		words[response]++
+		mass[response] += u.weight(i)
+	})
+	sum := 0.0
+	for response, n := range words {
+		sum += mass[response] * float64(n)
+	}
+	return sum / total
 }
 
 // OptimalGuessFrom returns the best guess for this solutions WordList based on
 // the available guesses in the guesses WordList.
 // The best guess:
-// - uses the most common letters based on letter frequency of words in this
-//   list
-// - returns a guess with the highest count of new letters that has the heaviest
-//   weighted-average letter frequency
+//   - uses the most common letters based on letter frequency of words in this
+//     list
+//   - returns a guess with the highest count of new letters that has the heaviest
+//     weighted-average letter frequency
+//
+// If this list has frequencies, letters are counted by the frequency of the
+// words they appear in, and ties go to the likeliest solution.
 func (solutions *WordList) OptimalGuessFrom(guesses *WordList) string {
 	if solutions.Length() == 0 || guesses.Length() == 0 {
 		return ""
 	}
-	// Count how many words each letter appears in (as opposed to how many times
-	// each letter shows up). Thus "forgo" increments "o" by 1, not 2.
-	counts := make(map[int32]int, 26)
-	for word, _ := range solutions.words {
-		seen := make(map[int32]bool, 26)
-		for _, c := range word {
-			if !seen[c] {
-				counts[c] = counts[c] + 1
-				seen[c] = true
-			}
-		}
-	}
+	counts := solutions.letterMass()
 
 	// identify the most diverse / heaviest word
 	var (
-		heaviest         string
-		max, mostDiverse int
+		heaviest    string
+		max         float64
+		mostDiverse int
 	)
 
 	// TODO: optimize further!
@@ -184,14 +343,15 @@ func (solutions *WordList) OptimalGuessFrom(guesses *WordList) string {
 	// guess. But this algorithm chooses "forum" -- because it has 5 different
 	// letters. We could be smarter and ignore the "for" prefix, which would
 	// then result in choosing forgo or fordo.
-	for word, _ := range guesses.words {
-		weight := 0
-		uniq := make(map[int32]bool)
-		for _, c := range word {
+	for _, word := range guesses.list() {
+		weight, diversity := 0.0, 0
+		letters := []rune(word)
+		for i, c := range letters {
 			weight += counts[c]
-			uniq[c] = true
+			if !containsRune(letters[:i], c) {
+				diversity++
+			}
 		}
-		diversity := len(uniq)
 		switch {
 		case diversity < mostDiverse:
 			// do nothing
@@ -202,13 +362,83 @@ func (solutions *WordList) OptimalGuessFrom(guesses *WordList) string {
 		case weight > max: // diversity == mostDiverse
 			heaviest = word
 			max = weight
-		case weight == max && solutions.Contains(word) && !solutions.Contains(heaviest):
+		case weight == max && solutions.Frequency(word) > solutions.Frequency(heaviest):
 			heaviest = word
 		}
 	}
 	return heaviest
 }
 
+// letterCounts counts how many words each letter appears in (as opposed to how
+// many times each letter shows up). Thus "forgo" increments "o" by 1, not 2.
+func (w *WordList) letterCounts() map[rune]int {
+	counts := make(map[rune]int, 26)
+	if w.Length() == 0 {
+		return counts
+	}
+	u := w.words.u
+	u.buildIndex()
+	for c, l := range u.alphabet {
+		counts[c] = w.words.bits.countAnd(u.counts[l][0])
+	}
+	return counts
+}
+
+// letterMass is letterCounts weighted by Frequency: it sums the weight of the
+// words each letter appears in.
+func (w *WordList) letterMass() map[rune]float64 {
+	mass := make(map[rune]float64, 26)
+	if w.Length() > 0 && w.words.u.freq == nil {
+		for c, n := range w.letterCounts() {
+			mass[c] = float64(n)
+		}
+		return mass
+	}
+	var seen []rune
+	for _, word := range w.list() {
+		seen = seen[:0]
+		f := w.Frequency(word)
+		for _, c := range word {
+			if !containsRune(seen, c) {
+				seen = append(seen, c)
+				mass[c] += f
+			}
+		}
+	}
+	return mass
+}
+
+// containsRune returns true if c is in runes.
+func containsRune(runes []rune, c rune) bool {
+	for _, r := range runes {
+		if r == c {
+			return true
+		}
+	}
+	return false
+}
+
+// Weights returns the letter-frequency weight of each word in the WordList: the
+// sum, over the word's letters, of the number of words in the WordList that
+// contain the letter. OptimalGuessFrom prefers heavier guesses.
+func (w *WordList) Weights() map[string]int {
+	weights := make(map[string]int, w.Length())
+	counts := w.letterCounts()
+	for _, word := range w.list() {
+		for _, c := range word {
+			weights[word] += counts[c]
+		}
+	}
+	return weights
+}
+
+// Sorted returns the words in the WordList in alphabetical order.
+func (w *WordList) Sorted() []string {
+	sorted := w.list()
+	sort.Strings(sorted)
+	return sorted
+}
+
 // OptimalGuess calls OptimalGuessFrom with this WordList as both the guess
 // list and solution set.
 func (w *WordList) OptimalGuess() string {
@@ -218,6 +448,9 @@ func (w *WordList) OptimalGuess() string {
 // Option represents a constraint to place on a WordList.
 type Option interface {
 	apply(*WordList)
+	// key identifies the Option, so that loaders can cache the WordLists
+	// they filter with it.
+	key() string
 }
 
 // KeepOnlyOption specifies that Solver should only include words that match
@@ -231,6 +464,11 @@ func (k KeepOnlyOption) apply(w *WordList) {
 	w.KeepOnly(k.Exp)
 }
 
+// key fulfills the Option interface
+func (k KeepOnlyOption) key() string {
+	return "keep " + k.Exp.String()
+}
+
 // DeleteOption specifies that Solver should exclude words that match
 // the given expression.
 type DeleteOption struct {
@@ -241,3 +479,8 @@ type DeleteOption struct {
 func (d DeleteOption) apply(w *WordList) {
 	w.Delete(d.Exp)
 }
+
+// key fulfills the Option interface
+func (d DeleteOption) key() string {
+	return "delete " + d.Exp.String()
+}
@@ -1,7 +1,9 @@
 package wordlist
 
 import (
+	"reflect"
 	"regexp"
+	"sync"
 	"testing"
 )
 
@@ -113,6 +115,18 @@ func TestKeepOnly(t *testing.T) {
 	}
 }
 
+func TestKeepOnlyFunc(t *testing.T) {
+	w := New([]string{"foo", "bar", "bam", "zoo"})
+	c := w.Clone()
+	w.KeepOnlyFunc(func(word string) bool { return word[0] == 'b' })
+	if want := New([]string{"bar", "bam"}); !w.Equals(want) {
+		t.Errorf("want %#v, got %#v", want, w)
+	}
+	if c.Length() != 4 {
+		t.Errorf("want clone unchanged, got %#v", c)
+	}
+}
+
 func TestNew(t *testing.T) {
 	baseList := []string{"foo", "bar", "bam", "zoo"}
 	l := baseList[:]
@@ -276,3 +290,180 @@ func TestOptimalGuessFrom(t *testing.T) {
 		})
 	}
 }
+
+func TestBuckets(t *testing.T) {
+	w := New([]string{"crane", "crate", "trace", "sloth"})
+	want := map[string]int{"+++++": 1, "+++_+": 1, "*++*+": 1, "___+_": 1}
+	if got := w.Buckets("crate"); !reflect.DeepEqual(want, got) {
+		t.Errorf("want %v, got %v", want, got)
+	}
+	if want, got := 1.0, w.ExpectedRemaining("crate"); want != got {
+		t.Errorf("want %v, got %v", want, got)
+	}
+	// "zzzzz" can't tell any of the words apart.
+	if want, got := 4.0, w.ExpectedRemaining("zzzzz"); want != got {
+		t.Errorf("want %v, got %v", want, got)
+	}
+
+	w = nil
+	if got := w.Buckets("crate"); len(got) != 0 {
+		t.Errorf("want no buckets, got %v", got)
+	}
+	if want, got := 0.0, w.ExpectedRemaining("crate"); want != got {
+		t.Errorf("want %v, got %v", want, got)
+	}
+}
+
+func TestSorted(t *testing.T) {
+	want := []string{"bam", "bar", "foo", "zoo"}
+	w := New([]string{"foo", "bar", "zoo", "bam"})
+	if got := w.Sorted(); !reflect.DeepEqual(want, got) {
+		t.Errorf("want %v, got %v", want, got)
+	}
+
+	if got := New([]string{}).Sorted(); len(got) != 0 {
+		t.Errorf("want empty list, got %v", got)
+	}
+	w = nil
+	if got := w.Sorted(); got != nil {
+		t.Errorf("want nil, got %v", got)
+	}
+}
+
+func TestWeights(t *testing.T) {
+	w := New([]string{"ab", "bb", "bc"})
+	// a appears in 1 word, b in 3 and c in 1.
+	want := map[string]int{"ab": 4, "bb": 6, "bc": 4}
+	if got := w.Weights(); !reflect.DeepEqual(want, got) {
+		t.Errorf("want %v, got %v", want, got)
+	}
+
+	w = nil
+	if got := w.Weights(); len(got) != 0 {
+		t.Errorf("want no weights, got %v", got)
+	}
+}
+
+func TestLetterFilters(t *testing.T) {
+	baseList := []string{"foo", "bar", "bam", "zoo", "oof", "boo"}
+	cases := []struct {
+		desc   string
+		filter func(w *WordList)
+		want   []string
+	}{
+		{"KeepOnlyAt(0, b)", func(w *WordList) { w.KeepOnlyAt(0, 'b') }, []string{"bar", "bam", "boo"}},
+		{"KeepOnlyAt(2, o)", func(w *WordList) { w.KeepOnlyAt(2, 'o') }, []string{"foo", "zoo", "boo"}},
+		{"KeepOnlyAt(3, o)", func(w *WordList) { w.KeepOnlyAt(3, 'o') }, nil},
+		{"KeepOnlyAt(0, x)", func(w *WordList) { w.KeepOnlyAt(0, 'x') }, nil},
+		{"DeleteAt(0, b)", func(w *WordList) { w.DeleteAt(0, 'b') }, []string{"foo", "zoo", "oof"}},
+		{"DeleteAt(0, x)", func(w *WordList) { w.DeleteAt(0, 'x') }, baseList},
+		{"KeepOnlyContaining(o, 1)", func(w *WordList) { w.KeepOnlyContaining('o', 1) }, []string{"foo", "zoo", "oof", "boo"}},
+		{"KeepOnlyContaining(o, 2)", func(w *WordList) { w.KeepOnlyContaining('o', 2) }, []string{"foo", "zoo", "oof", "boo"}},
+		{"KeepOnlyContaining(f, 1)", func(w *WordList) { w.KeepOnlyContaining('f', 1) }, []string{"foo", "oof"}},
+		{"KeepOnlyContaining(o, 3)", func(w *WordList) { w.KeepOnlyContaining('o', 3) }, nil},
+		{"KeepOnlyContaining(o, 0)", func(w *WordList) { w.KeepOnlyContaining('o', 0) }, baseList},
+		{"DeleteContaining(o, 1)", func(w *WordList) { w.DeleteContaining('o', 1) }, []string{"bar", "bam"}},
+		{"DeleteContaining(a, 2)", func(w *WordList) { w.DeleteContaining('a', 2) }, baseList},
+		{"DeleteContaining(x, 1)", func(w *WordList) { w.DeleteContaining('x', 1) }, baseList},
+		{"DeleteContaining(o, 0)", func(w *WordList) { w.DeleteContaining('o', 0) }, nil},
+		{
+			"KeepOnlyAt(1, a) + DeleteAt(2, m)",
+			func(w *WordList) { w.KeepOnlyAt(1, 'a'); w.DeleteAt(2, 'm') },
+			[]string{"bar"},
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.desc, func(t *testing.T) {
+			w := New(baseList)
+			c.filter(w)
+			if want := New(c.want); !want.Equals(w) {
+				t.Errorf("want %#v, got %#v", want, w)
+			}
+		})
+	}
+
+	// Don't panic on empty lists.
+	for _, w := range []*WordList{nil, {}, New(nil)} {
+		w.KeepOnlyAt(0, 'a')
+		w.DeleteAt(0, 'a')
+		w.KeepOnlyContaining('a', 1)
+		w.DeleteContaining('a', 1)
+		if w.Length() != 0 {
+			t.Errorf("want empty list, got %#v", w)
+		}
+	}
+}
+
+func TestClone(t *testing.T) {
+	baseList := []string{"foo", "bar", "bam", "zoo"}
+	w := New(baseList)
+	c := w.Clone()
+	if !w.Equals(c) {
+		t.Errorf("want %#v, got %#v", w, c)
+	}
+
+	// Changing c should have no effect on w.
+	c.KeepOnlyAt(0, 'b')
+	if want := New(baseList); !want.Equals(w) {
+		t.Errorf("want %#v, got %#v", want, w)
+	}
+	if want := New([]string{"bar", "bam"}); !want.Equals(c) {
+		t.Errorf("want %#v, got %#v", want, c)
+	}
+
+	// Clones share their words until they're changed; changing either one
+	// leaves the others alone.
+	c1, c2 := w.Clone(), w.Clone()
+	w.Delete(regexp.MustCompile("^z"))
+	c1.DeleteContaining('o', 1)
+	c2.DeleteContaining('a', 0)
+	for _, c := range []struct{ got, want *WordList }{
+		{w, New([]string{"foo", "bar", "bam"})},
+		{c1, New([]string{"bar", "bam"})},
+		{c2, New(nil)},
+		{c2.Clone(), New(nil)},
+		{c1.Clone(), New([]string{"bar", "bam"})},
+	} {
+		if !c.want.Equals(c.got) {
+			t.Errorf("want %#v, got %#v", c.want, c.got)
+		}
+	}
+
+	// Cloning is safe from many goroutines at once (run with -race), and
+	// changing a clone leaves the list alone.
+	w = New(baseList)
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			w.Clone().KeepOnlyAt(0, 'z')
+		}()
+	}
+	wg.Wait()
+	if want := New(baseList); !want.Equals(w) {
+		t.Errorf("want %#v, got %#v", want, w)
+	}
+}
+
+func TestRandom(t *testing.T) {
+	baseList := []string{"foo", "bar", "bam", "zoo"}
+	w := New(baseList)
+	seen := make(map[string]bool)
+	for i := 0; i < 1000; i++ {
+		word := w.Random()
+		if !w.Contains(word) {
+			t.Fatalf("random word %q is not in %#v", word, w)
+		}
+		seen[word] = true
+	}
+	if want, got := len(baseList), len(seen); want != got {
+		t.Errorf("want all %d words to be chosen eventually, got %v", want, seen)
+	}
+
+	w = nil
+	if got := w.Random(); got != "" {
+		t.Errorf("want empty string, got %v", got)
+	}
+}